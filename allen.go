@@ -0,0 +1,180 @@
+package intervals
+
+import (
+	"sort"
+	"strconv"
+)
+
+// A Relation is one of the 13 basic relations of Allen's interval algebra,
+// which are jointly exhaustive and pairwise disjoint: exactly one of them
+// holds between any two non-empty valid Intervals.
+//
+// Relations are defined on half-open Intervals: r meets s if r.High equals
+// s.Low, in which case r and s are adjacent and have no element in common;
+// r is before s if there is a gap between them.
+//
+// Note that RelOverlaps and RelContains are strict: the Overlaps and Contains
+// methods of Set, for example, also hold for Intervals that are related by
+// RelEquals, RelStarts, RelFinishes and others.
+type Relation int
+
+const (
+	RelBefore       Relation = iota // r.High < s.Low
+	RelMeets                        // r.High == s.Low
+	RelOverlaps                     // r.Low < s.Low < r.High < s.High
+	RelStarts                       // r.Low == s.Low, r.High < s.High
+	RelDuring                       // s.Low < r.Low, r.High < s.High
+	RelFinishes                     // s.Low < r.Low, r.High == s.High
+	RelEquals                       // r.Low == s.Low, r.High == s.High
+	RelFinishedBy                   // r.Low < s.Low, r.High == s.High
+	RelContains                     // r.Low < s.Low, s.High < r.High
+	RelStartedBy                    // r.Low == s.Low, s.High < r.High
+	RelOverlappedBy                 // s.Low < r.Low < s.High < r.High
+	RelMetBy                        // r.Low == s.High
+	RelAfter                        // s.High < r.Low
+)
+
+var relationNames = [...]string{
+	"Before", "Meets", "Overlaps", "Starts", "During", "Finishes", "Equals",
+	"FinishedBy", "Contains", "StartedBy", "OverlappedBy", "MetBy", "After",
+}
+
+func (rel Relation) String() string {
+	if rel >= 0 && int(rel) < len(relationNames) {
+		return relationNames[rel]
+	}
+
+	return "Relation(" + strconv.Itoa(int(rel)) + ")"
+}
+
+// Inverse returns the converse of rel, i.e. the relation between s and r
+// if rel is the relation between r and s.
+func (rel Relation) Inverse() Relation {
+	return RelAfter - rel
+}
+
+// Relation returns the relation between r and s.
+// Relation panics if r or s is empty or invalid.
+func (r Interval[E]) Relation(s Interval[E]) Relation {
+	if r.Low.Compare(r.High) >= 0 || s.Low.Compare(s.High) >= 0 {
+		panic("invalid Interval")
+	}
+
+	if c := r.High.Compare(s.Low); c <= 0 {
+		if c < 0 {
+			return RelBefore
+		}
+
+		return RelMeets
+	}
+
+	if c := r.Low.Compare(s.High); c >= 0 {
+		if c > 0 {
+			return RelAfter
+		}
+
+		return RelMetBy
+	}
+
+	switch lo, hi := r.Low.Compare(s.Low), r.High.Compare(s.High); {
+	case lo < 0 && hi < 0:
+		return RelOverlaps
+	case lo < 0 && hi == 0:
+		return RelFinishedBy
+	case lo < 0:
+		return RelContains
+	case lo == 0 && hi < 0:
+		return RelStarts
+	case lo == 0 && hi == 0:
+		return RelEquals
+	case lo == 0:
+		return RelStartedBy
+	case hi < 0:
+		return RelDuring
+	case hi == 0:
+		return RelFinishes
+	default:
+		return RelOverlappedBy
+	}
+}
+
+// Before reports whether r ends before s starts, with a gap between them.
+// Before panics if r or s is empty or invalid.
+func (r Interval[E]) Before(s Interval[E]) bool { return r.Relation(s) == RelBefore }
+
+// After reports whether r starts after s ends, with a gap between them.
+// After panics if r or s is empty or invalid.
+func (r Interval[E]) After(s Interval[E]) bool { return r.Relation(s) == RelAfter }
+
+// Meets reports whether r ends where s starts.
+// Meets panics if r or s is empty or invalid.
+func (r Interval[E]) Meets(s Interval[E]) bool { return r.Relation(s) == RelMeets }
+
+// MetBy reports whether r starts where s ends.
+// MetBy panics if r or s is empty or invalid.
+func (r Interval[E]) MetBy(s Interval[E]) bool { return r.Relation(s) == RelMetBy }
+
+// Starts reports whether r and s start together, and r ends before s does.
+// Starts panics if r or s is empty or invalid.
+func (r Interval[E]) Starts(s Interval[E]) bool { return r.Relation(s) == RelStarts }
+
+// StartedBy reports whether r and s start together, and s ends before r does.
+// StartedBy panics if r or s is empty or invalid.
+func (r Interval[E]) StartedBy(s Interval[E]) bool { return r.Relation(s) == RelStartedBy }
+
+// Finishes reports whether r and s end together, and s starts before r does.
+// Finishes panics if r or s is empty or invalid.
+func (r Interval[E]) Finishes(s Interval[E]) bool { return r.Relation(s) == RelFinishes }
+
+// FinishedBy reports whether r and s end together, and r starts before s
+// does. FinishedBy panics if r or s is empty or invalid.
+func (r Interval[E]) FinishedBy(s Interval[E]) bool { return r.Relation(s) == RelFinishedBy }
+
+// During reports whether r starts after s starts and ends before s ends.
+// During panics if r or s is empty or invalid.
+func (r Interval[E]) During(s Interval[E]) bool { return r.Relation(s) == RelDuring }
+
+// AnyRelation reports whether rel holds between some Interval in x and some
+// Interval in y.
+//
+// AnyRelation runs in O((n+m) log m) time, where n and m are the numbers of
+// Intervals in x and y.
+func AnyRelation[E Elem[E]](x, y Set[E], rel Relation) bool {
+	if len(x) == 0 || len(y) == 0 {
+		return false
+	}
+
+	switch rel {
+	case RelBefore:
+		return x[0].High.Compare(y[len(y)-1].Low) < 0
+	case RelAfter:
+		return x[len(x)-1].Low.Compare(y[0].High) > 0
+	}
+
+	// Other relations only hold between Intervals that overlap or touch.
+	for _, r := range x {
+		i := sort.Search(len(y), func(i int) bool { return y[i].High.Compare(r.Low) >= 0 })
+
+		for ; i < len(y) && y[i].Low.Compare(r.High) <= 0; i++ {
+			if r.Relation(y[i]) == rel {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Precedes reports whether some Interval in x precedes all Intervals in y,
+// i.e. x and y are not empty, and the first Interval in x is before or meets
+// the first Interval in y.
+func (x Set[E]) Precedes(y Set[E]) bool {
+	return len(x) != 0 && len(y) != 0 && x[0].High.Compare(y[0].Low) <= 0
+}
+
+// Follows reports whether some Interval in x follows all Intervals in y,
+// i.e. x and y are not empty, and the last Interval in x is after or met by
+// the last Interval in y.
+func (x Set[E]) Follows(y Set[E]) bool {
+	return len(x) != 0 && len(y) != 0 && x[len(x)-1].Low.Compare(y[len(y)-1].High) >= 0
+}
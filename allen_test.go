@@ -0,0 +1,133 @@
+package intervals_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestRelation(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		R, S     Interval[E]
+		Expected Relation
+	}{
+		{Interval[E]{1, 3}, Interval[E]{4, 6}, RelBefore},
+		{Interval[E]{1, 3}, Interval[E]{3, 6}, RelMeets},
+		{Interval[E]{1, 4}, Interval[E]{3, 6}, RelOverlaps},
+		{Interval[E]{1, 4}, Interval[E]{1, 6}, RelStarts},
+		{Interval[E]{2, 4}, Interval[E]{1, 6}, RelDuring},
+		{Interval[E]{2, 6}, Interval[E]{1, 6}, RelFinishes},
+		{Interval[E]{1, 6}, Interval[E]{1, 6}, RelEquals},
+		{Interval[E]{1, 6}, Interval[E]{2, 6}, RelFinishedBy},
+		{Interval[E]{1, 6}, Interval[E]{2, 4}, RelContains},
+		{Interval[E]{1, 6}, Interval[E]{1, 4}, RelStartedBy},
+		{Interval[E]{3, 6}, Interval[E]{1, 4}, RelOverlappedBy},
+		{Interval[E]{3, 6}, Interval[E]{1, 3}, RelMetBy},
+		{Interval[E]{4, 6}, Interval[E]{1, 3}, RelAfter},
+	}
+
+	for i, c := range testCases {
+		if rel := c.R.Relation(c.S); rel != c.Expected {
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, rel)
+			t.Fail()
+		}
+
+		if rel := c.S.Relation(c.R); rel != c.Expected.Inverse() {
+			t.Logf("Case %v: want inverse %v, but got %v", i, c.Expected.Inverse(), rel)
+			t.Fail()
+		}
+	}
+
+	r, s := Interval[E]{1, 3}, Interval[E]{3, 6}
+
+	assertions := []bool{
+		Interval[E]{1, 2}.Before(s),
+		!r.Before(s),
+		s.After(Interval[E]{1, 2}),
+		r.Meets(s),
+		s.MetBy(r),
+		Interval[E]{1, 2}.Starts(r),
+		r.StartedBy(Interval[E]{1, 2}),
+		Interval[E]{2, 3}.Finishes(r),
+		r.FinishedBy(Interval[E]{2, 3}),
+		Interval[E]{4, 5}.During(s),
+		!s.During(s),
+		RelBefore.String() == "Before",
+		RelOverlappedBy.String() == "OverlappedBy",
+		RelAfter.String() == "After",
+		Relation(13).String() == "Relation(13)",
+		RelEquals.Inverse() == RelEquals,
+		RelMeets.Inverse() == RelMetBy,
+		RelDuring.Inverse() == RelContains,
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+
+	shouldPanic(t, func() { Interval[E]{}.Relation(s) }, "Relation(empty)")
+	shouldPanic(t, func() { r.Relation(Interval[E]{5, 1}) }, "Relation(invalid)")
+}
+
+func TestAnyRelation(t *testing.T) {
+	type E = elems.Int
+
+	rnd := rand.New(rand.NewSource(1))
+
+	randomSet := func() Set[E] {
+		var x Set[E]
+
+		for i := rnd.Intn(5); i > 0; i-- {
+			lo := E(rnd.Intn(30))
+			x = Add(x, Interval[E]{lo, lo + 1 + E(rnd.Intn(6))})
+		}
+
+		return x
+	}
+
+	for n := 0; n < 2000; n++ {
+		x, y := randomSet(), randomSet()
+
+		for rel := RelBefore; rel <= RelAfter; rel++ {
+			expected := false
+
+			for _, r := range x {
+				for _, s := range y {
+					if r.Relation(s) == rel {
+						expected = true
+					}
+				}
+			}
+
+			if got := AnyRelation(x, y, rel); got != expected {
+				t.Fatalf("AnyRelation(%v, %v, %v): want %v, but got %v", x, y, rel, expected, got)
+			}
+		}
+
+		precedes, follows := false, false
+
+		for _, r := range x {
+			all, all2 := len(y) != 0, len(y) != 0
+
+			for _, s := range y {
+				rel := r.Relation(s)
+				all = all && (rel == RelBefore || rel == RelMeets)
+				all2 = all2 && (rel == RelAfter || rel == RelMetBy)
+			}
+
+			precedes = precedes || all
+			follows = follows || all2
+		}
+
+		if x.Precedes(y) != precedes || x.Follows(y) != follows {
+			t.Fatalf("%v.Precedes(%v) = %v, Follows = %v; want %v, %v", x, y, x.Precedes(y), x.Follows(y), precedes, follows)
+		}
+	}
+}
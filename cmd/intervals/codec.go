@@ -0,0 +1,123 @@
+package main
+
+import (
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// A codec parses and formats elements of type E.
+type codec[E intervals.Enum[E]] struct {
+	parse  func(string) (E, error)
+	format func(E) string
+	json   func(E) any
+
+	// next, if not nil, replaces the Next method of E for computing the High
+	// of an Interval that ends at an element.
+	next func(E) E
+
+	// prefix, if not nil, parses a string that denotes a range of elements.
+	// It reports false if the string does not look like one.
+	prefix func(string) (intervals.Interval[E], bool, error)
+}
+
+// nextOf returns the element next to v.
+func (c codec[E]) nextOf(v E) E {
+	if c.next != nil {
+		return c.next(v)
+	}
+
+	return v.Next()
+}
+
+var intCodec = codec[elems.Int64]{
+	parse: func(s string) (elems.Int64, error) {
+		v, err := strconv.ParseInt(s, 0, 64)
+		return elems.Int64(v), err
+	},
+	format: func(v elems.Int64) string { return strconv.FormatInt(int64(v), 10) },
+	json:   func(v elems.Int64) any { return int64(v) },
+}
+
+var uintCodec = codec[elems.Uint64]{
+	parse: func(s string) (elems.Uint64, error) {
+		v, err := strconv.ParseUint(s, 0, 64)
+		return elems.Uint64(v), err
+	},
+	format: func(v elems.Uint64) string { return strconv.FormatUint(uint64(v), 10) },
+	json:   func(v elems.Uint64) any { return uint64(v) },
+}
+
+var floatCodec = codec[elems.Float64]{
+	parse: func(s string) (elems.Float64, error) {
+		v, err := strconv.ParseFloat(s, 64)
+		return elems.Float64(v), err
+	},
+	format: func(v elems.Float64) string { return strconv.FormatFloat(float64(v), 'g', -1, 64) },
+	json: func(v elems.Float64) any {
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return strconv.FormatFloat(float64(v), 'g', -1, 64)
+		}
+
+		return float64(v)
+	},
+}
+
+var timeCodec = codec[elems.Time]{
+	parse: func(s string) (elems.Time, error) {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		return elems.Time(t), err
+	},
+	format: elems.Time.String,
+	json:   func(v elems.Time) any { return v.String() },
+}
+
+var ipCodec = codec[netip.Addr]{
+	parse:  netip.ParseAddr,
+	format: netip.Addr.String,
+	json:   func(v netip.Addr) any { return v.String() },
+	next:   nextAddr,
+	prefix: func(s string) (intervals.Interval[netip.Addr], bool, error) {
+		if !strings.Contains(s, "/") {
+			return intervals.Interval[netip.Addr]{}, false, nil
+		}
+
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return intervals.Interval[netip.Addr]{}, true, err
+		}
+
+		return intervals.Range(p.Masked().Addr(), nextAddr(lastAddr(p))), true, nil
+	},
+}
+
+// nextAddr returns the address next to a. Since netip.Addr.Compare sorts
+// IPv4 addresses before IPv6 ones, the address next to 255.255.255.255 is
+// the first IPv6 address, ::. There is no address next to the last IPv6
+// address; nextAddr returns the zero Addr, like netip.Addr.Next does.
+func nextAddr(a netip.Addr) netip.Addr {
+	if n := a.Next(); n.IsValid() || !a.Is4() {
+		return n
+	}
+
+	return netip.IPv6Unspecified()
+}
+
+// lastAddr returns the last address in p.
+func lastAddr(p netip.Prefix) netip.Addr {
+	a := p.Masked().Addr()
+	b := a.AsSlice()
+
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+
+	last, _ := netip.AddrFromSlice(b)
+
+	return last.WithZone(a.Zone())
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/b97tsk/intervals"
+)
+
+func execute[E intervals.Enum[E]](c codec[E], opts options) error {
+	inputs, err := readInputs(c, opts)
+	if err != nil {
+		return err
+	}
+
+	sets := make([]intervals.Set[E], len(inputs))
+	for i, s := range inputs {
+		sets[i] = intervals.Collect(s...)
+	}
+
+	switch opts.command {
+	case "union", "merge":
+		return writeSet(c, opts, intervals.Reduce(intervals.Union[E], sets...))
+	case "intersect":
+		return writeSet(c, opts, intervals.Reduce(intervals.Intersection[E], sets...))
+	case "symdiff":
+		return writeSet(c, opts, intervals.Reduce(intervals.SymmetricDifference[E], sets...))
+	case "subtract":
+		x := sets[0]
+		if len(sets) > 1 {
+			x = x.Difference(intervals.Reduce(intervals.Union[E], sets[1:]...))
+		}
+
+		return writeSet(c, opts, x)
+	case "complement":
+		x := intervals.Reduce(intervals.Union[E], sets...)
+		universe := x.Extent()
+
+		if opts.low != "" {
+			if universe.Low, err = c.parse(opts.low); err != nil {
+				return fmt.Errorf("-low: %w", err)
+			}
+		}
+
+		if opts.high != "" {
+			if universe.High, err = c.parse(opts.high); err != nil {
+				return fmt.Errorf("-high: %w", err)
+			}
+		}
+
+		if universe.Low.Compare(universe.High) >= 0 {
+			return writeSet(c, opts, nil)
+		}
+
+		return writeSet(c, opts, universe.Set().Difference(x))
+	case "coverage":
+		var all []intervals.Interval[E]
+		for _, s := range inputs {
+			all = append(all, s...)
+		}
+
+		return writeCoverage(c, opts, coverage(all))
+	case "contains":
+		if len(inputs) != 2 {
+			return errors.New("contains: expected two inputs")
+		}
+
+		return writeContains(c, opts, sets[0], inputs[1])
+	default:
+		return fmt.Errorf("unknown command %q", opts.command)
+	}
+}
+
+// readInputs reads intervals from each input file, or from the standard
+// input if there is none. Empty intervals are dropped.
+func readInputs[E intervals.Enum[E]](c codec[E], opts options) ([][]intervals.Interval[E], error) {
+	files := opts.files
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	inputs := make([][]intervals.Interval[E], len(files))
+
+	for i, name := range files {
+		var err error
+
+		if name == "-" {
+			inputs[i], err = readIntervals(c, opts.stdin)
+		} else {
+			var f *os.File
+
+			if f, err = os.Open(name); err != nil {
+				return nil, err
+			}
+
+			inputs[i], err = readIntervals(c, f)
+			f.Close()
+		}
+
+		if err != nil {
+			if name == "-" {
+				name = "<stdin>"
+			}
+
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return inputs, nil
+}
+
+func readIntervals[E intervals.Enum[E]](c codec[E], r io.Reader) ([]intervals.Interval[E], error) {
+	var s []intervals.Interval[E]
+
+	sc := bufio.NewScanner(r)
+
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		r, err := parseInterval(c, text)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", line, err)
+		}
+
+		if r.Low.Compare(r.High) < 0 {
+			s = append(s, r)
+		}
+	}
+
+	return s, sc.Err()
+}
+
+func parseInterval[E intervals.Enum[E]](c codec[E], text string) (intervals.Interval[E], error) {
+	var r intervals.Interval[E]
+
+	fields := strings.FieldsFunc(text, func(c rune) bool {
+		return c == ',' || unicode.IsSpace(c)
+	})
+
+	switch len(fields) {
+	case 1:
+		if c.prefix != nil {
+			if p, ok, err := c.prefix(fields[0]); ok {
+				if err == nil && p.Low.Compare(p.High) >= 0 {
+					err = fmt.Errorf("%s: range includes the maximum element", fields[0])
+				}
+
+				return p, err
+			}
+		}
+
+		v, err := c.parse(fields[0])
+		if err != nil {
+			return r, err
+		}
+
+		if r = intervals.Range(v, c.nextOf(v)); r.Low.Compare(r.High) >= 0 {
+			return r, fmt.Errorf("%s: range includes the maximum element", fields[0])
+		}
+	case 2:
+		lo, err := c.parse(fields[0])
+		if err != nil {
+			return r, err
+		}
+
+		hi, err := c.parse(fields[1])
+		if err != nil {
+			return r, err
+		}
+
+		if r = intervals.Range(lo, hi); r.Low.Compare(r.High) > 0 {
+			return r, fmt.Errorf("invalid interval [%s, %s)", fields[0], fields[1])
+		}
+	default:
+		return r, fmt.Errorf("expected one or two fields, got %d", len(fields))
+	}
+
+	return r, nil
+}
+
+type segment[E intervals.Elem[E]] struct {
+	intervals.Interval[E]
+	Depth int
+}
+
+// coverage returns, in ascending order, maximal segments covered by at least
+// one interval in s, along with the number of intervals covering them.
+func coverage[E intervals.Elem[E]](s []intervals.Interval[E]) []segment[E] {
+	type event struct {
+		at    E
+		delta int
+	}
+
+	events := make([]event, 0, len(s)*2)
+	for _, r := range s {
+		events = append(events, event{r.Low, +1}, event{r.High, -1})
+	}
+
+	slices.SortFunc(events, func(a, b event) int { return a.at.Compare(b.at) })
+
+	var segs []segment[E]
+
+	depth := 0
+
+	for i := 0; i < len(events); {
+		at := events[i].at
+
+		for ; i < len(events) && events[i].at.Compare(at) == 0; i++ {
+			depth += events[i].delta
+		}
+
+		if depth == 0 || i == len(events) {
+			continue
+		}
+
+		if n := len(segs); n > 0 && segs[n-1].Depth == depth && segs[n-1].High.Compare(at) == 0 {
+			segs[n-1].High = events[i].at
+			continue
+		}
+
+		segs = append(segs, segment[E]{intervals.Range(at, events[i].at), depth})
+	}
+
+	return segs
+}
+
+func writeSet[E intervals.Enum[E]](c codec[E], opts options, x intervals.Set[E]) error {
+	w := bufio.NewWriter(opts.stdout)
+
+	if opts.format == "json" {
+		type item struct {
+			Low  any `json:"low"`
+			High any `json:"high"`
+		}
+
+		out := make([]item, len(x))
+		for i, r := range x {
+			out[i] = item{c.json(r.Low), c.json(r.High)}
+		}
+
+		return writeJSON(w, out)
+	}
+
+	for _, r := range x {
+		fmt.Fprintf(w, "%s\t%s\n", c.format(r.Low), c.format(r.High))
+	}
+
+	return w.Flush()
+}
+
+func writeCoverage[E intervals.Enum[E]](c codec[E], opts options, segs []segment[E]) error {
+	w := bufio.NewWriter(opts.stdout)
+
+	if opts.format == "json" {
+		type item struct {
+			Low   any `json:"low"`
+			High  any `json:"high"`
+			Depth int `json:"depth"`
+		}
+
+		out := make([]item, len(segs))
+		for i, s := range segs {
+			out[i] = item{c.json(s.Low), c.json(s.High), s.Depth}
+		}
+
+		return writeJSON(w, out)
+	}
+
+	for _, s := range segs {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", c.format(s.Low), c.format(s.High), s.Depth)
+	}
+
+	return w.Flush()
+}
+
+func writeContains[E intervals.Enum[E]](c codec[E], opts options, x intervals.Set[E], queries []intervals.Interval[E]) error {
+	w := bufio.NewWriter(opts.stdout)
+
+	if opts.format == "json" {
+		type item struct {
+			Low       any  `json:"low"`
+			High      any  `json:"high"`
+			Contained bool `json:"contained"`
+		}
+
+		out := make([]item, len(queries))
+		for i, r := range queries {
+			out[i] = item{c.json(r.Low), c.json(r.High), x.Contains(r)}
+		}
+
+		return writeJSON(w, out)
+	}
+
+	for _, r := range queries {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", c.format(r.Low), c.format(r.High), x.Contains(r))
+	}
+
+	return w.Flush()
+}
+
+func writeJSON(w *bufio.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestCoverage(t *testing.T) {
+	type E = elems.Int
+
+	r := func(lo, hi E) intervals.Interval[E] { return intervals.Range(lo, hi) }
+	seg := func(lo, hi E, depth int) segment[E] { return segment[E]{r(lo, hi), depth} }
+
+	testCases := []struct {
+		Actual, Expected []segment[E]
+	}{
+		{coverage[E](nil), nil},
+		{coverage([]intervals.Interval[E]{r(1, 3)}), []segment[E]{seg(1, 3, 1)}},
+		{coverage([]intervals.Interval[E]{r(1, 3), r(1, 3)}), []segment[E]{seg(1, 3, 2)}},
+		{coverage([]intervals.Interval[E]{r(1, 3), r(3, 5)}), []segment[E]{seg(1, 5, 1)}},
+		{coverage([]intervals.Interval[E]{r(1, 3), r(4, 5)}), []segment[E]{seg(1, 3, 1), seg(4, 5, 1)}},
+		{coverage([]intervals.Interval[E]{r(1, 9), r(3, 5), r(4, 7)}), []segment[E]{seg(1, 3, 1), seg(3, 4, 2), seg(4, 5, 3), seg(5, 7, 2), seg(7, 9, 1)}},
+		{coverage([]intervals.Interval[E]{r(5, 7), r(1, 5), r(3, 5)}), []segment[E]{seg(1, 3, 1), seg(3, 5, 2), seg(5, 7, 1)}},
+	}
+
+	for i, c := range testCases {
+		if !equalSegments(c.Actual, c.Expected) {
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+			t.Fail()
+		}
+	}
+}
+
+func equalSegments[E intervals.Elem[E]](x, y []segment[E]) bool {
+	if len(x) != len(y) {
+		return false
+	}
+
+	for i := range x {
+		if !x[i].Equal(y[i].Interval) || x[i].Depth != y[i].Depth {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,125 @@
+// Command intervals performs set algebra on intervals read from text files.
+//
+// Usage:
+//
+//	intervals [flags] command [file ...]
+//
+// Each line of an input file holds an interval, written as its low and high
+// bounds separated by spaces, tabs or a comma. Intervals are half-open:
+// the low bound is inclusive and the high bound is exclusive. A line with
+// a single value holds an interval that contains only that value; for IP
+// addresses, a CIDR prefix such as 10.0.0.0/8 is also accepted. Blank lines
+// and lines starting with '#' are ignored. If no file is given, or a file
+// is "-", intervals are read from the standard input.
+//
+// IPv4 addresses are ordered before IPv6 addresses, so an IPv4 range that
+// ends at 255.255.255.255, such as 0.0.0.0/0, has "::", the first IPv6
+// address, as its exclusive high bound. Since there is no address after
+// the last IPv6 address, ranges that include it, such as ::/0, cannot be
+// represented and are rejected.
+//
+// The commands are:
+//
+//	union       elements that are in any of the files
+//	intersect   elements that are in all of the files
+//	subtract    elements that are in the first file, but not in the others
+//	symdiff     elements that are in an odd number of the files
+//	complement  elements that are not in any of the files, within the range
+//	            specified by -low and -high (default: the extent of inputs)
+//	merge       same as union; merges overlapping and adjacent intervals
+//	coverage    the number of intervals that cover each part of the inputs
+//	contains    for each interval in the second file, whether the first
+//	            file contains all of its elements
+//
+// The flags are:
+//
+//	-type string
+//	    element type: int, uint, float, time (RFC 3339) or ip (default "int")
+//	-format string
+//	    output format: text or json (default "text")
+//	-low string
+//	    lower bound (inclusive) of the range for complement
+//	-high string
+//	    upper bound (exclusive) of the range for complement
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "intervals:", err)
+		}
+
+		os.Exit(2)
+	}
+}
+
+type options struct {
+	command   string
+	files     []string
+	format    string
+	low, high string
+	stdin     io.Reader
+	stdout    io.Writer
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("intervals", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: intervals [flags] command [file ...]")
+		fmt.Fprintln(fs.Output(), "commands: union, intersect, subtract, symdiff, complement, merge, coverage, contains")
+		fmt.Fprintln(fs.Output(), "IPv4 ranges ending at 255.255.255.255 end at :: (exclusive); IPv6 ranges cannot include the last address")
+		fs.PrintDefaults()
+	}
+
+	typ := fs.String("type", "int", "element `type`: int, uint, float, time (RFC 3339) or ip")
+	format := fs.String("format", "text", "output `format`: text or json")
+	low := fs.String("low", "", "lower bound (inclusive) of the range for complement")
+	high := fs.String("high", "", "upper bound (exclusive) of the range for complement")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no command given")
+	}
+
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	opts := options{
+		command: fs.Arg(0),
+		files:   fs.Args()[1:],
+		format:  *format,
+		low:     *low,
+		high:    *high,
+		stdin:   stdin,
+		stdout:  stdout,
+	}
+
+	switch *typ {
+	case "int":
+		return execute(intCodec, opts)
+	case "uint":
+		return execute(uintCodec, opts)
+	case "float":
+		return execute(floatCodec, opts)
+	case "time":
+		return execute(timeCodec, opts)
+	case "ip":
+		return execute(ipCodec, opts)
+	default:
+		return fmt.Errorf("unknown type %q", *typ)
+	}
+}
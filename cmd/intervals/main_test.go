@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) string {
+		name = filepath.Join(dir, name)
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		return name
+	}
+
+	a := writeFile("a.txt", "# comment\n1 5\n3,8\n\n10\t12\n20\n")
+	b := writeFile("b.txt", "4 11\n")
+	q := writeFile("q.txt", "2 4\n7 11\n")
+	all := writeFile("all.txt", "0.0.0.0/0\n")
+
+	testCases := []struct {
+		Args     []string
+		Stdin    string
+		Expected string
+	}{
+		{[]string{"union", a, b}, "", "1\t12\n20\t21\n"},
+		{[]string{"merge", a}, "", "1\t8\n10\t12\n20\t21\n"},
+		{[]string{"intersect", a, b}, "", "4\t8\n10\t11\n"},
+		{[]string{"subtract", a, b}, "", "1\t4\n11\t12\n20\t21\n"},
+		{[]string{"symdiff", a, b}, "", "1\t4\n8\t10\n11\t12\n20\t21\n"},
+		{[]string{"complement", a}, "", "8\t10\n12\t20\n"},
+		{[]string{"-low", "0", "-high", "25", "complement", a}, "", "0\t1\n8\t10\n12\t20\n21\t25\n"},
+		{[]string{"coverage", a, b}, "", "1\t3\t1\n3\t4\t2\n4\t5\t3\n5\t8\t2\n8\t10\t1\n10\t11\t2\n11\t12\t1\n20\t21\t1\n"},
+		{[]string{"coverage"}, "1 3\n3 5\n", "1\t5\t1\n"},
+		{[]string{"contains", a, q}, "", "2\t4\ttrue\n7\t11\tfalse\n"},
+		{[]string{"union", "-", b}, "0 2\n", "0\t2\n4\t11\n"},
+		{[]string{"union"}, "5 5\n", ""},
+		{[]string{"-type", "uint", "union"}, "0x10 0x20\n", "16\t32\n"},
+		{[]string{"-type", "float", "union"}, "0.5 1\n1 1.5\n", "0.5\t1.5\n"},
+		{[]string{"-type", "time", "union"}, "2024-01-01T00:00:00Z 2024-01-02T00:00:00Z\n2024-01-02T00:00:00Z 2024-01-03T00:00:00Z\n", "2024-01-01T00:00:00Z\t2024-01-03T00:00:00Z\n"},
+		{[]string{"-type", "ip", "union"}, "10.0.0.0/8\n11.0.0.0\n::1\n", "10.0.0.0\t11.0.0.1\n::1\t::2\n"},
+		{[]string{"-type", "ip", "union"}, "192.168.1.77/24\n", "192.168.1.0\t192.168.2.0\n"},
+		{[]string{"-type", "ip", "union"}, "0.0.0.0/0\n", "0.0.0.0\t::\n"},
+		{[]string{"-type", "ip", "union"}, "240.0.0.0/4\n255.255.255.255\n", "240.0.0.0\t::\n"},
+		{[]string{"-type", "ip", "union"}, "255.255.255.255\n::/1\n", "255.255.255.255\t8000::\n"},
+		{[]string{"-type", "ip", "subtract", "-"}, "0.0.0.0/0\n", "0.0.0.0\t::\n"},
+		{[]string{"-type", "ip", "contains", all, "-"}, "255.255.255.255\n::1\n", "255.255.255.255\t::\ttrue\n::1\t::2\tfalse\n"},
+		{[]string{"-format", "json", "union"}, "1 2\n", "[\n  {\n    \"low\": 1,\n    \"high\": 2\n  }\n]\n"},
+		{[]string{"-format", "json", "union"}, "", "[]\n"},
+		{[]string{"-format", "json", "coverage"}, "1 2\n", "[\n  {\n    \"low\": 1,\n    \"high\": 2,\n    \"depth\": 1\n  }\n]\n"},
+		{[]string{"-format", "json", "-type", "float", "union"}, "-Inf 0\n", "[\n  {\n    \"low\": \"-Inf\",\n    \"high\": 0\n  }\n]\n"},
+		{[]string{"-format", "json", "-type", "ip", "union"}, "::1\n", "[\n  {\n    \"low\": \"::1\",\n    \"high\": \"::2\"\n  }\n]\n"},
+	}
+
+	for i, c := range testCases {
+		var stdout bytes.Buffer
+
+		if err := run(c.Args, strings.NewReader(c.Stdin), &stdout, io.Discard); err != nil {
+			t.Errorf("Case %v: unexpected error: %v", i, err)
+			continue
+		}
+
+		if stdout.String() != c.Expected {
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, stdout.String())
+			t.Fail()
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	testCases := []struct {
+		Args     []string
+		Stdin    string
+		Expected string
+	}{
+		{nil, "", "no command given"},
+		{[]string{"frobnicate"}, "", "unknown command"},
+		{[]string{"-type", "rune", "union"}, "", "unknown type"},
+		{[]string{"-format", "xml", "union"}, "", "unknown format"},
+		{[]string{"union"}, "1 2\n3 x\n", "<stdin>: 2: "},
+		{[]string{"union"}, "5 3\n", "invalid interval"},
+		{[]string{"union"}, "1 2 3\n", "expected one or two fields"},
+		{[]string{"-type", "ip", "union"}, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff\n", "maximum element"},
+		{[]string{"-type", "ip", "union"}, "::/0\n", "maximum element"},
+		{[]string{"-low", "x", "complement"}, "", "-low"},
+		{[]string{"contains"}, "", "expected two inputs"},
+		{[]string{"union", filepath.Join(t.TempDir(), "missing.txt")}, "", "missing.txt"},
+	}
+
+	for i, c := range testCases {
+		err := run(c.Args, strings.NewReader(c.Stdin), io.Discard, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), c.Expected) {
+			t.Logf("Case %v: want error containing %q, but got %v", i, c.Expected, err)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package intervals
+
+import "slices"
+
+// MinCover returns indices of a smallest subset of candidates whose union
+// covers as much of target as candidates do, in ascending order of the
+// intervals, along with the part of target that candidates cannot cover,
+// which is empty if target is fully covered.
+// Empty or invalid intervals in candidates are never selected.
+//
+// MinCover uses the greedy algorithm which, starting from the lowest
+// uncovered element, repeatedly selects the candidate that covers it and
+// reaches farthest. It runs in O(n log n + m) time, where n is the number
+// of candidates and m is the number of intervals in target.
+func MinCover[E Elem[E]](target Set[E], candidates []Interval[E]) (selected []int, uncovered Set[E]) {
+	order := make([]int, 0, len(candidates))
+
+	for i, r := range candidates {
+		if r.Low.Compare(r.High) < 0 {
+			order = append(order, i)
+		}
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		return candidates[i].Low.Compare(candidates[j].Low)
+	})
+
+	union := make(Set[E], len(order))
+	for i, j := range order {
+		union[i] = candidates[j]
+	}
+
+	coverable := target.Intersection(CollectInto(union, union...))
+	uncovered = target.Difference(coverable)
+
+	k, best := 0, -1
+
+	var reach E
+
+	for _, r := range coverable {
+		cur := r.Low
+		if len(selected) != 0 && reach.Compare(cur) > 0 {
+			cur = reach
+		}
+
+		for cur.Compare(r.High) < 0 {
+			for ; k < len(order) && candidates[order[k]].Low.Compare(cur) <= 0; k++ {
+				if best < 0 || candidates[order[k]].High.Compare(candidates[best].High) > 0 {
+					best = order[k]
+				}
+			}
+
+			// Since cur is coverable, candidates[best] must contain cur.
+			selected = append(selected, best)
+			reach = candidates[best].High
+			cur = reach
+		}
+	}
+
+	return selected, uncovered
+}
@@ -0,0 +1,104 @@
+package intervals_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestMinCover(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Target     Set[E]
+		Candidates []Interval[E]
+		Expected   []int
+		Uncovered  Set[E]
+	}{
+		{nil, nil, nil, nil},
+		{Set[E]{{1, 5}}, nil, nil, Set[E]{{1, 5}}},
+		{nil, []Interval[E]{{1, 5}}, nil, nil},
+		{Set[E]{{1, 5}}, []Interval[E]{{0, 9}}, []int{0}, nil},
+		{Set[E]{{1, 5}}, []Interval[E]{{3, 5}, {1, 3}}, []int{1, 0}, nil},
+		{Set[E]{{1, 9}}, []Interval[E]{{1, 4}, {2, 6}, {3, 8}, {5, 9}, {7, 9}}, []int{0, 2, 3}, nil},
+		{Set[E]{{1, 9}}, []Interval[E]{{1, 3}, {4, 6}, {7, 12}}, []int{0, 1, 2}, Set[E]{{3, 4}, {6, 7}}},
+		{Set[E]{{1, 3}, {5, 7}, {9, 11}}, []Interval[E]{{2, 6}, {0, 2}, {6, 10}, {10, 12}}, []int{1, 0, 2, 3}, nil},
+		{Set[E]{{1, 3}, {5, 7}, {9, 11}}, []Interval[E]{{0, 20}, {1, 3}}, []int{0}, nil},
+		{Set[E]{{1, 3}, {5, 7}}, []Interval[E]{{3, 5}, {7, 9}}, nil, Set[E]{{1, 3}, {5, 7}}},
+		{Set[E]{{1, 5}}, []Interval[E]{{3, 1}, {2, 2}, {1, 5}}, []int{2}, nil},
+	}
+
+	for i, c := range testCases {
+		selected, uncovered := MinCover(c.Target, c.Candidates)
+		if !slices.Equal(selected, c.Expected) || !uncovered.Equal(c.Uncovered) {
+			t.Logf("Case %v: want %v %v, but got %v %v", i, c.Expected, c.Uncovered, selected, uncovered)
+			t.Fail()
+		}
+	}
+}
+
+func TestMinCoverRandom(t *testing.T) {
+	type E = elems.Int
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 500; n++ {
+		var target Set[E]
+		for i := rnd.Intn(4); i > 0; i-- {
+			lo := E(rnd.Intn(30))
+			target = Add(target, Interval[E]{lo, lo + 1 + E(rnd.Intn(8))})
+		}
+
+		candidates := make([]Interval[E], rnd.Intn(12))
+		for i := range candidates {
+			lo := E(rnd.Intn(30))
+			candidates[i] = Interval[E]{lo, lo + 1 + E(rnd.Intn(10))}
+		}
+
+		unionOf := func(indices []int) Set[E] {
+			var x Set[E]
+			for _, i := range indices {
+				x = Add(x, candidates[i])
+			}
+
+			return x
+		}
+
+		all := make([]int, len(candidates))
+		for i := range all {
+			all[i] = i
+		}
+
+		coverable := target.Intersection(unionOf(all))
+
+		minCount := len(candidates)
+
+		for mask := 0; mask < 1<<len(candidates); mask++ {
+			var indices []int
+			for i := range candidates {
+				if mask&(1<<i) != 0 {
+					indices = append(indices, i)
+				}
+			}
+
+			if coverable.IsSubsetOf(unionOf(indices)) {
+				minCount = min(minCount, bits.OnesCount(uint(mask)))
+			}
+		}
+
+		selected, uncovered := MinCover(target, candidates)
+
+		switch {
+		case len(selected) != minCount:
+			t.Fatalf("MinCover(%v, %v): want %v candidates, but got %v", target, candidates, minCount, selected)
+		case !coverable.IsSubsetOf(unionOf(selected)):
+			t.Fatalf("MinCover(%v, %v): %v does not cover %v", target, candidates, selected, coverable)
+		case !uncovered.Equal(target.Difference(coverable)):
+			t.Fatalf("MinCover(%v, %v): want uncovered %v, but got %v", target, candidates, target.Difference(coverable), uncovered)
+		}
+	}
+}
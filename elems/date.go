@@ -0,0 +1,99 @@
+package elems
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+)
+
+// A Date is a calendar day, without time or time zone.
+// The zero value is not a valid Date; use IsValid to check.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date in which t occurs, in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+// ParseDate parses a string in ISO 8601 extended format, i.e. YYYY-MM-DD.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, err
+	}
+
+	return DateOf(t), nil
+}
+
+// String returns d in ISO 8601 extended format, i.e. YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid reports whether d represents an existing calendar day.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the time at midnight starting d, in location loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns d plus n days. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+// DaysSince returns the number of days from u to d. The result is negative
+// if d comes before u.
+func (d Date) DaysSince(u Date) int {
+	return int((d.In(time.UTC).Unix() - u.In(time.UTC).Unix()) / (24 * 60 * 60))
+}
+
+// Weekday returns the day of the week specified by d.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+func (d Date) Compare(e Date) int {
+	if c := cmp.Compare(d.Year, e.Year); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(d.Month, e.Month); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(d.Day, e.Day)
+}
+
+// Next returns the day after d.
+func (d Date) Next() Date { return d.AddDays(1) }
+
+// Prev returns the day before d.
+func (d Date) Prev() Date { return d.AddDays(-1) }
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The output is the same as d.String().
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The input is expected to be in the format accepted by ParseDate.
+func (d *Date) UnmarshalText(data []byte) error {
+	v, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
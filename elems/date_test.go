@@ -0,0 +1,59 @@
+package elems_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestDate(t *testing.T) {
+	d := elems.Date{Year: 2024, Month: time.February, Day: 28}
+
+	assert(t, d.Compare(d) == 0, "Compare didn't return 0.")
+	assert(t, d.Compare(d.Next()) == -1, "Compare didn't return -1.")
+	assert(t, d.Next().Compare(d) == +1, "Compare didn't return +1.")
+	assert(t, d.Next() == elems.Date{Year: 2024, Month: time.February, Day: 29}, "Next didn't work.")
+	assert(t, d.Next().Next() == elems.Date{Year: 2024, Month: time.March, Day: 1}, "Next twice didn't work.")
+	assert(t, d.Next().Next().Prev().Prev() == d, "Prev twice didn't work.")
+	assert(t, d.AddDays(-59) == elems.Date{Year: 2023, Month: time.December, Day: 31}, "AddDays didn't work.")
+	assert(t, d.AddDays(366).DaysSince(d) == 366, "DaysSince didn't work.")
+	assert(t, d.DaysSince(d.AddDays(100000)) == -100000, "DaysSince didn't work for distant dates.")
+	assert(t, d.Weekday() == time.Wednesday, "Weekday didn't work.")
+	assert(t, d.IsValid(), "IsValid didn't return true.")
+	assert(t, !(elems.Date{Year: 2023, Month: time.February, Day: 29}).IsValid(), "IsValid didn't return false.")
+	assert(t, !(elems.Date{}).IsValid(), "IsValid didn't return false for the zero value.")
+	assert(t, elems.DateOf(time.Date(2024, 2, 28, 23, 59, 0, 0, time.UTC)) == d, "DateOf didn't work.")
+	assert(t, d.In(time.UTC).Equal(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)), "In didn't work.")
+	assert(t, intervals.Unit(d).Set().ContainsUnit(d), "Unit(d) didn't contain d.")
+	assert(t, !intervals.Unit(d).Set().ContainsUnit(d.Next()), "Unit(d) contained d.Next().")
+}
+
+func TestDateText(t *testing.T) {
+	d, err := elems.ParseDate("2024-02-29")
+
+	assert(t, err == nil, "ParseDate failed.")
+	assert(t, d == elems.Date{Year: 2024, Month: time.February, Day: 29}, "ParseDate didn't work.")
+	assert(t, d.String() == "2024-02-29", "String didn't work.")
+
+	_, err = elems.ParseDate("2023-02-29")
+	assert(t, err != nil, "ParseDate didn't fail on an invalid date.")
+
+	_, err = elems.ParseDate("2024/02/29")
+	assert(t, err != nil, "ParseDate didn't fail on a malformed date.")
+
+	data, err := json.Marshal(intervals.Range(d, d.AddDays(7)))
+	assert(t, err == nil, "json.Marshal failed.")
+	assert(t, string(data) == `{"Low":"2024-02-29","High":"2024-03-07"}`, "MarshalText didn't work.")
+
+	var r intervals.Interval[elems.Date]
+
+	err = json.Unmarshal(data, &r)
+	assert(t, err == nil, "json.Unmarshal failed.")
+	assert(t, r == intervals.Range(d, d.AddDays(7)), "UnmarshalText didn't work.")
+
+	err = json.Unmarshal([]byte(`{"Low":"2024-13-01"}`), &r)
+	assert(t, err != nil, "UnmarshalText didn't fail on an invalid date.")
+}
@@ -1,2 +1,3 @@
-// Package elems provides Elem implementations for built-in numeric types.
+// Package elems provides Elem implementations for built-in numeric types,
+// times, calendar dates and tuples.
 package elems
@@ -1,8 +1,10 @@
 package elems_test
 
 import (
+	"math"
 	"testing"
 
+	"github.com/b97tsk/intervals"
 	"github.com/b97tsk/intervals/elems"
 )
 
@@ -34,6 +36,8 @@ type Integer interface {
 type FloatElem[E, U any] interface {
 	Float
 	Compare(E) int
+	Next() E
+	Prev() E
 	Unwrap() U
 }
 
@@ -41,6 +45,7 @@ type IntegerElem[E, U any] interface {
 	Integer
 	Compare(E) int
 	Next() E
+	Prev() E
 	Unwrap() U
 }
 
@@ -51,6 +56,50 @@ func testFloat[E FloatElem[E, U], U Float](t *testing.T) {
 	assert(t, x.Compare(x+1) == -1, "Compare didn't return -1.")
 	assert(t, (x+1).Compare(x) == +1, "Compare didn't return +1.")
 	assert(t, x.Unwrap() == U(0), "Unwrap didn't work.")
+
+	nan := E(math.NaN())
+	inf := E(math.Inf(+1))
+	negInf := E(math.Inf(-1))
+	negZero := E(math.Copysign(0, -1))
+
+	assert(t, nan.Compare(nan) == 0, "NaN didn't equal to NaN.")
+	assert(t, nan.Compare(negInf) == -1, "NaN wasn't less than -Inf.")
+	assert(t, negInf.Compare(nan) == +1, "-Inf wasn't greater than NaN.")
+	assert(t, negZero.Compare(x) == -1, "-0 wasn't less than +0.")
+	assert(t, x.Compare(negZero) == +1, "+0 wasn't greater than -0.")
+	assert(t, negZero.Compare(negZero) == 0, "-0 didn't equal to -0.")
+	assert(t, nan.Next().Compare(negInf) == 0, "Next(NaN) didn't return -Inf.")
+	assert(t, negZero.Next().Compare(x) == 0, "Next(-0) didn't return +0.")
+	assert(t, x.Next() > 0, "Next(+0) didn't return a positive number.")
+	assert(t, x.Next().Compare(x) == +1, "Next(+0) wasn't greater than +0.")
+	assert(t, inf.Next().Compare(inf) == 0, "Next(+Inf) didn't return +Inf.")
+	assert(t, (-x.Next()).Next().Compare(negZero) == 0, "Next(-SmallestNonzero) didn't return -0.")
+	assert(t, x.Prev().Compare(negZero) == 0, "Prev(+0) didn't return -0.")
+	assert(t, negZero.Prev().Compare(-x.Next()) == 0, "Prev(-0) didn't return -SmallestNonzero.")
+	assert(t, negInf.Prev().Compare(nan) == 0, "Prev(-Inf) didn't return NaN.")
+	assert(t, nan.Prev().Compare(nan) == 0, "Prev(NaN) didn't return NaN.")
+	assert(t, inf.Prev().Next().Compare(inf) == 0, "Next(Prev(+Inf)) didn't return +Inf.")
+	assert(t, (x+1).Prev().Next().Compare(x+1) == 0, "Next(Prev(1)) didn't return 1.")
+
+	assert(t, intervals.Unit(nan).Set().ContainsUnit(nan), "Unit(NaN) didn't contain NaN.")
+	assert(t, !intervals.Unit(nan).Set().ContainsUnit(negInf), "Unit(NaN) contained -Inf.")
+	assert(t, intervals.Unit(negZero).Set().ContainsUnit(negZero), "Unit(-0) didn't contain -0.")
+	assert(t, !intervals.Unit(negZero).Set().ContainsUnit(x), "Unit(-0) contained +0.")
+	assert(t, !intervals.Unit(inf).IsValid(), "Unit(+Inf) was valid.")
+}
+
+func TestFloatMax(t *testing.T) {
+	r := intervals.Unit(elems.Float64(math.MaxFloat64))
+
+	assert(t, r.IsValid(), "Unit(MaxFloat64) was invalid.")
+	assert(t, r.High.Unwrap() == math.Inf(+1), "Unit(MaxFloat64).High wasn't +Inf.")
+	assert(t, r.Set().ContainsUnit(math.MaxFloat64), "Unit(MaxFloat64) didn't contain MaxFloat64.")
+	assert(t, !r.Set().ContainsUnit(elems.Float64(math.Inf(+1))), "Unit(MaxFloat64) contained +Inf.")
+
+	r32 := intervals.Unit(elems.Float32(math.MaxFloat32))
+
+	assert(t, r32.IsValid(), "Unit(MaxFloat32) was invalid.")
+	assert(t, r32.High.Unwrap() == float32(math.Inf(+1)), "Unit(MaxFloat32).High wasn't +Inf.")
 }
 
 func testInteger[E IntegerElem[E, U], U Integer](t *testing.T) {
@@ -61,6 +110,8 @@ func testInteger[E IntegerElem[E, U], U Integer](t *testing.T) {
 	assert(t, x.Next().Compare(x) == +1, "Compare didn't return +1.")
 	assert(t, x.Next().Unwrap() == 1, "Next didn't work.")
 	assert(t, x.Next().Next().Unwrap() == 2, "Next twice didn't work.")
+	assert(t, x.Next().Prev() == x, "Prev didn't work.")
+	assert(t, x.Next().Next().Prev().Prev() == x, "Prev twice didn't work.")
 }
 
 func assert(t *testing.T, ok bool, message string) {
@@ -1,15 +1,96 @@
 package elems
 
-import "cmp"
+import (
+	"cmp"
+	"math"
+)
 
+// Float32 is a float32 Elem whose Compare method defines a total order:
+// NaN < -Inf < ... < -0 < +0 < ... < +Inf.
+// All NaNs are considered equal.
 type Float32 float32
 
-func (x Float32) Compare(y Float32) int { return cmp.Compare(x, y) }
+func (x Float32) Compare(y Float32) int { return compareFloat(x, y) }
+
+// Next returns the smallest Float32 that is greater than x.
+// Next(NaN) returns -Inf. Next(+Inf) returns +Inf.
+func (x Float32) Next() Float32 {
+	switch {
+	case x != x:
+		return Float32(math.Inf(-1))
+	case x == 0 && math.Signbit(float64(x)):
+		return 0
+	}
+
+	return Float32(math.Nextafter32(float32(x), float32(math.Inf(+1))))
+}
+
+// Prev returns the largest Float32 that is less than x.
+// Prev(+0) returns -0. Prev(-Inf) returns NaN. Prev(NaN) returns NaN.
+func (x Float32) Prev() Float32 {
+	switch {
+	case x != x:
+		return x
+	case x == 0 && !math.Signbit(float64(x)):
+		return Float32(math.Copysign(0, -1))
+	case math.IsInf(float64(x), -1):
+		return Float32(math.NaN())
+	}
+
+	return Float32(math.Nextafter32(float32(x), float32(math.Inf(-1))))
+}
 
 func (x Float32) Unwrap() float32 { return float32(x) }
 
+// Float64 is a float64 Elem whose Compare method defines a total order:
+// NaN < -Inf < ... < -0 < +0 < ... < +Inf.
+// All NaNs are considered equal.
 type Float64 float64
 
-func (x Float64) Compare(y Float64) int { return cmp.Compare(x, y) }
+func (x Float64) Compare(y Float64) int { return compareFloat(x, y) }
+
+// Next returns the smallest Float64 that is greater than x.
+// Next(NaN) returns -Inf. Next(+Inf) returns +Inf.
+func (x Float64) Next() Float64 {
+	switch {
+	case x != x:
+		return Float64(math.Inf(-1))
+	case x == 0 && math.Signbit(float64(x)):
+		return 0
+	}
+
+	return Float64(math.Nextafter(float64(x), math.Inf(+1)))
+}
+
+// Prev returns the largest Float64 that is less than x.
+// Prev(+0) returns -0. Prev(-Inf) returns NaN. Prev(NaN) returns NaN.
+func (x Float64) Prev() Float64 {
+	switch {
+	case x != x:
+		return x
+	case x == 0 && !math.Signbit(float64(x)):
+		return Float64(math.Copysign(0, -1))
+	case math.IsInf(float64(x), -1):
+		return Float64(math.NaN())
+	}
+
+	return Float64(math.Nextafter(float64(x), math.Inf(-1)))
+}
 
 func (x Float64) Unwrap() float64 { return float64(x) }
+
+func compareFloat[F ~float32 | ~float64](x, y F) int {
+	if c := cmp.Compare(x, y); c != 0 || x != 0 {
+		return c
+	}
+
+	// Both are zeros; -0 comes first.
+	switch sx, sy := math.Signbit(float64(x)), math.Signbit(float64(y)); {
+	case sx == sy:
+		return 0
+	case sx:
+		return -1
+	default:
+		return +1
+	}
+}
@@ -0,0 +1,32 @@
+package elems
+
+// biEnum is the same as intervals.BiEnum, defined here so that this package
+// does not depend on package intervals.
+type biEnum[T any] interface {
+	enum[T]
+	Prev() T
+}
+
+// Reverse wraps an element of E, inverting its order.
+type Reverse[E elem[E]] struct {
+	V E
+}
+
+func (x Reverse[E]) Compare(y Reverse[E]) int { return y.V.Compare(x.V) }
+
+func (x Reverse[E]) Unwrap() E { return x.V }
+
+// A BiEnumReverse is a Reverse of a BiEnum.
+// A BiEnumReverse is a BiEnum too, whose Next method calls the Prev method
+// of E, and vice versa.
+type BiEnumReverse[E biEnum[E]] struct {
+	V E
+}
+
+func (x BiEnumReverse[E]) Compare(y BiEnumReverse[E]) int { return y.V.Compare(x.V) }
+
+func (x BiEnumReverse[E]) Next() BiEnumReverse[E] { return BiEnumReverse[E]{x.V.Prev()} }
+
+func (x BiEnumReverse[E]) Prev() BiEnumReverse[E] { return BiEnumReverse[E]{x.V.Next()} }
+
+func (x BiEnumReverse[E]) Unwrap() E { return x.V }
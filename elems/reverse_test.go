@@ -0,0 +1,43 @@
+package elems_test
+
+import (
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestReverse(t *testing.T) {
+	type R = elems.Reverse[elems.Pair[elems.Int, elems.Int]]
+	type P = elems.Pair[elems.Int, elems.Int]
+
+	assert(t, R{P{1, 2}}.Compare(R{P{1, 2}}) == 0, "Compare didn't return 0.")
+	assert(t, R{P{1, 2}}.Compare(R{P{1, 3}}) == +1, "Compare didn't return +1.")
+	assert(t, R{P{2, 0}}.Compare(R{P{1, 9}}) == -1, "Compare didn't return -1.")
+	assert(t, R{P{1, 2}}.Unwrap() == P{1, 2}, "Unwrap didn't work.")
+
+	x := intervals.Collect(intervals.Range(R{P{9, 0}}, R{P{7, 0}}))
+
+	assert(t, x.ContainsUnit(R{P{8, 5}}), "ContainsUnit didn't return true.")
+	assert(t, !x.ContainsUnit(R{P{7, 0}}), "ContainsUnit didn't return false.")
+	assert(t, !x.ContainsUnit(R{P{9, 1}}), "ContainsUnit didn't return false.")
+}
+
+func TestBiEnumReverse(t *testing.T) {
+	type R = elems.BiEnumReverse[elems.Int]
+
+	assert(t, R{1}.Compare(R{1}) == 0, "Compare didn't return 0.")
+	assert(t, R{1}.Compare(R{2}) == +1, "Compare didn't return +1.")
+	assert(t, R{2}.Compare(R{1}) == -1, "Compare didn't return -1.")
+	assert(t, R{1}.Next() == R{0}, "Next didn't work.")
+	assert(t, R{1}.Prev() == R{2}, "Prev didn't work.")
+	assert(t, R{1}.Unwrap() == 1, "Unwrap didn't work.")
+
+	x := intervals.Collect(intervals.Range(R{9}, R{7}), intervals.Range(R{5}, R{2}))
+
+	assert(t, x.ContainsUnit(R{9}), "ContainsUnit didn't return true.")
+	assert(t, x.ContainsUnit(R{3}), "ContainsUnit didn't return true.")
+	assert(t, !x.ContainsUnit(R{7}), "ContainsUnit didn't return false.")
+	assert(t, !x.ContainsUnit(R{2}), "ContainsUnit didn't return false.")
+	assert(t, !x.ContainsUnit(R{10}), "ContainsUnit didn't return false.")
+}
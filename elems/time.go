@@ -0,0 +1,18 @@
+package elems
+
+import "time"
+
+// Time is a time.Time Elem, ordered by time instant.
+// Its enumeration steps by one nanosecond.
+type Time time.Time
+
+func (x Time) Compare(y Time) int { return time.Time(x).Compare(time.Time(y)) }
+
+func (x Time) Next() Time { return Time(time.Time(x).Add(time.Nanosecond)) }
+
+func (x Time) Prev() Time { return Time(time.Time(x).Add(-time.Nanosecond)) }
+
+func (x Time) Unwrap() time.Time { return time.Time(x) }
+
+// String returns x formatted in RFC 3339 format with nanoseconds.
+func (x Time) String() string { return time.Time(x).Format(time.RFC3339Nano) }
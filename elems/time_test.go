@@ -0,0 +1,27 @@
+package elems_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestTime(t *testing.T) {
+	t0 := time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC)
+	x := elems.Time(t0)
+
+	assert(t, x.Compare(x) == 0, "Compare didn't return 0.")
+	assert(t, x.Compare(x.Next()) == -1, "Compare didn't return -1.")
+	assert(t, x.Next().Compare(x) == +1, "Compare didn't return +1.")
+	assert(t, x.Compare(elems.Time(t0.In(time.FixedZone("X", 3600)))) == 0, "Compare didn't ignore locations.")
+	assert(t, x.Next().Unwrap().Sub(t0) == time.Nanosecond, "Next didn't work.")
+	assert(t, x.Next().Prev().Unwrap().Equal(t0), "Prev didn't work.")
+	assert(t, x.String() == "2024-02-29T09:00:00Z", "String didn't work.")
+
+	s := intervals.Range(x, elems.Time(t0.Add(time.Hour))).Set()
+
+	assert(t, s.ContainsUnit(elems.Time(t0.Add(time.Minute))), "ContainsUnit didn't return true.")
+	assert(t, !s.ContainsUnit(elems.Time(t0.Add(time.Hour))), "ContainsUnit didn't return false.")
+}
@@ -0,0 +1,90 @@
+package elems
+
+// elem and enum are the same as intervals.Elem and intervals.Enum, defined
+// here so that this package does not depend on package intervals.
+type elem[T any] interface {
+	Compare(T) int
+}
+
+type enum[T any] interface {
+	elem[T]
+	Next() T
+}
+
+// A Pair is an Elem made of two components, ordered lexicographically.
+type Pair[A elem[A], B elem[B]] struct {
+	First  A
+	Second B
+}
+
+func (x Pair[A, B]) Compare(y Pair[A, B]) int {
+	if c := x.First.Compare(y.First); c != 0 {
+		return c
+	}
+
+	return x.Second.Compare(y.Second)
+}
+
+// An EnumPair is a Pair whose last component is an Enum.
+// An EnumPair is an Enum too.
+type EnumPair[A elem[A], B enum[B]] struct {
+	First  A
+	Second B
+}
+
+func (x EnumPair[A, B]) Compare(y EnumPair[A, B]) int {
+	if c := x.First.Compare(y.First); c != 0 {
+		return c
+	}
+
+	return x.Second.Compare(y.Second)
+}
+
+// Next returns x with its last component advanced by one.
+func (x EnumPair[A, B]) Next() EnumPair[A, B] {
+	return EnumPair[A, B]{x.First, x.Second.Next()}
+}
+
+// A Triple is an Elem made of three components, ordered lexicographically.
+type Triple[A elem[A], B elem[B], C elem[C]] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+func (x Triple[A, B, C]) Compare(y Triple[A, B, C]) int {
+	if c := x.First.Compare(y.First); c != 0 {
+		return c
+	}
+
+	if c := x.Second.Compare(y.Second); c != 0 {
+		return c
+	}
+
+	return x.Third.Compare(y.Third)
+}
+
+// An EnumTriple is a Triple whose last component is an Enum.
+// An EnumTriple is an Enum too.
+type EnumTriple[A elem[A], B elem[B], C enum[C]] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+func (x EnumTriple[A, B, C]) Compare(y EnumTriple[A, B, C]) int {
+	if c := x.First.Compare(y.First); c != 0 {
+		return c
+	}
+
+	if c := x.Second.Compare(y.Second); c != 0 {
+		return c
+	}
+
+	return x.Third.Compare(y.Third)
+}
+
+// Next returns x with its last component advanced by one.
+func (x EnumTriple[A, B, C]) Next() EnumTriple[A, B, C] {
+	return EnumTriple[A, B, C]{x.First, x.Second, x.Third.Next()}
+}
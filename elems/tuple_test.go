@@ -0,0 +1,74 @@
+package elems_test
+
+import (
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+type String string
+
+func (x String) Compare(y String) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	default:
+		return 0
+	}
+}
+
+func TestPair(t *testing.T) {
+	type P = elems.Pair[String, elems.Float64]
+
+	assert(t, P{"a", 1}.Compare(P{"a", 1}) == 0, "Compare didn't return 0.")
+	assert(t, P{"a", 1}.Compare(P{"a", 2}) == -1, "Compare didn't return -1.")
+	assert(t, P{"a", 2}.Compare(P{"b", 1}) == -1, "Compare didn't return -1.")
+	assert(t, P{"b", 1}.Compare(P{"a", 2}) == +1, "Compare didn't return +1.")
+
+	x := intervals.Range(P{"topic-3", 100}, P{"topic-5", 0}).Set()
+
+	assert(t, x.ContainsUnit(P{"topic-3", 100}), "ContainsUnit didn't return true.")
+	assert(t, x.ContainsUnit(P{"topic-4", -1}), "ContainsUnit didn't return true.")
+	assert(t, !x.ContainsUnit(P{"topic-3", 99}), "ContainsUnit didn't return false.")
+	assert(t, !x.ContainsUnit(P{"topic-5", 0}), "ContainsUnit didn't return false.")
+}
+
+func TestEnumPair(t *testing.T) {
+	type P = elems.EnumPair[String, elems.Int64]
+
+	assert(t, P{"a", 1}.Compare(P{"a", 1}) == 0, "Compare didn't return 0.")
+	assert(t, P{"a", 1}.Compare(P{"a", 2}) == -1, "Compare didn't return -1.")
+	assert(t, P{"b", 1}.Compare(P{"a", 2}) == +1, "Compare didn't return +1.")
+	assert(t, P{"a", 1}.Next() == P{"a", 2}, "Next didn't work.")
+
+	x := intervals.Collect(intervals.Unit(P{"topic-3", 100}), intervals.Unit(P{"topic-3", 101}))
+
+	assert(t, x.Equal(intervals.Range(P{"topic-3", 100}, P{"topic-3", 102}).Set()), "Unit didn't work.")
+}
+
+func TestTriple(t *testing.T) {
+	type T = elems.Triple[elems.Int, elems.Int, String]
+
+	assert(t, T{1, 2, "a"}.Compare(T{1, 2, "a"}) == 0, "Compare didn't return 0.")
+	assert(t, T{1, 2, "a"}.Compare(T{1, 2, "b"}) == -1, "Compare didn't return -1.")
+	assert(t, T{1, 2, "b"}.Compare(T{1, 3, "a"}) == -1, "Compare didn't return -1.")
+	assert(t, T{2, 0, "a"}.Compare(T{1, 3, "b"}) == +1, "Compare didn't return +1.")
+}
+
+func TestEnumTriple(t *testing.T) {
+	type V = elems.EnumTriple[elems.Int, elems.Int, elems.Int]
+
+	assert(t, V{1, 2, 3}.Compare(V{1, 2, 3}) == 0, "Compare didn't return 0.")
+	assert(t, V{1, 2, 3}.Compare(V{1, 3, 0}) == -1, "Compare didn't return -1.")
+	assert(t, V{2, 0, 0}.Compare(V{1, 9, 9}) == +1, "Compare didn't return +1.")
+	assert(t, V{1, 2, 3}.Next() == V{1, 2, 4}, "Next didn't work.")
+
+	x := intervals.Range(V{1, 2, 0}, V{2, 0, 0}).Set()
+
+	assert(t, x.ContainsUnit(V{1, 9, 9}), "ContainsUnit didn't return true.")
+	assert(t, !x.ContainsUnit(V{2, 0, 0}), "ContainsUnit didn't return false.")
+	assert(t, !x.Contains(intervals.Unit(V{1, 1, 9})), "Contains didn't return false.")
+}
@@ -8,6 +8,8 @@ func (x Uint) Compare(y Uint) int { return cmp.Compare(x, y) }
 
 func (x Uint) Next() Uint { return x + 1 }
 
+func (x Uint) Prev() Uint { return x - 1 }
+
 func (x Uint) Unwrap() uint { return uint(x) }
 
 type Uint8 uint8
@@ -16,6 +18,8 @@ func (x Uint8) Compare(y Uint8) int { return cmp.Compare(x, y) }
 
 func (x Uint8) Next() Uint8 { return x + 1 }
 
+func (x Uint8) Prev() Uint8 { return x - 1 }
+
 func (x Uint8) Unwrap() uint8 { return uint8(x) }
 
 type Uint16 uint16
@@ -24,6 +28,8 @@ func (x Uint16) Compare(y Uint16) int { return cmp.Compare(x, y) }
 
 func (x Uint16) Next() Uint16 { return x + 1 }
 
+func (x Uint16) Prev() Uint16 { return x - 1 }
+
 func (x Uint16) Unwrap() uint16 { return uint16(x) }
 
 type Uint32 uint32
@@ -32,6 +38,8 @@ func (x Uint32) Compare(y Uint32) int { return cmp.Compare(x, y) }
 
 func (x Uint32) Next() Uint32 { return x + 1 }
 
+func (x Uint32) Prev() Uint32 { return x - 1 }
+
 func (x Uint32) Unwrap() uint32 { return uint32(x) }
 
 type Uint64 uint64
@@ -40,6 +48,8 @@ func (x Uint64) Compare(y Uint64) int { return cmp.Compare(x, y) }
 
 func (x Uint64) Next() Uint64 { return x + 1 }
 
+func (x Uint64) Prev() Uint64 { return x - 1 }
+
 func (x Uint64) Unwrap() uint64 { return uint64(x) }
 
 type Uintptr uintptr
@@ -48,4 +58,6 @@ func (x Uintptr) Compare(y Uintptr) int { return cmp.Compare(x, y) }
 
 func (x Uintptr) Next() Uintptr { return x + 1 }
 
+func (x Uintptr) Prev() Uintptr { return x - 1 }
+
 func (x Uintptr) Unwrap() uintptr { return uintptr(x) }
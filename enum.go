@@ -0,0 +1,73 @@
+package intervals
+
+// First returns the smallest element in r.
+// If r contains no elements, First returns the zero value and false.
+func First[E Elem[E]](r Interval[E]) (E, bool) {
+	if r.Low.Compare(r.High) >= 0 {
+		var zero E
+		return zero, false
+	}
+
+	return r.Low, true
+}
+
+// Last returns the largest element in r, i.e. r.High.Prev().
+// If r contains no elements, Last returns the zero value and false.
+func Last[E BiEnum[E]](r Interval[E]) (E, bool) {
+	if r.Low.Compare(r.High) >= 0 {
+		var zero E
+		return zero, false
+	}
+
+	return r.High.Prev(), true
+}
+
+// Min returns the smallest element in x.
+// If x is empty, Min returns the zero value and false.
+func Min[E Elem[E]](x Set[E]) (E, bool) {
+	if len(x) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	return x[0].Low, true
+}
+
+// Max returns the largest element in x.
+// If x is empty, Max returns the zero value and false.
+func Max[E BiEnum[E]](x Set[E]) (E, bool) {
+	if len(x) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	return x[len(x)-1].High.Prev(), true
+}
+
+// Ascend calls yield for each element in x, in ascending order, until yield
+// returns false.
+func Ascend[E Enum[E]](x Set[E], yield func(E) bool) {
+	for _, r := range x {
+		for v := r.Low; v.Compare(r.High) < 0; v = v.Next() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Descend calls yield for each element in x, in descending order, until yield
+// returns false.
+func Descend[E BiEnum[E]](x Set[E], yield func(E) bool) {
+	for i := len(x) - 1; i >= 0; i-- {
+		r := x[i]
+
+		for v := r.High; v.Compare(r.Low) > 0; {
+			v = v.Prev()
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package intervals_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestFirstLast(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Interval Interval[E]
+		First    E
+		Last     E
+		OK       bool
+	}{
+		{Range[E](1, 5), 1, 4, true},
+		{Unit[E](3), 3, 3, true},
+		{Interval[E]{}, 0, 0, false},
+		{Range[E](5, 1), 0, 0, false},
+	}
+
+	for i, c := range testCases {
+		first, ok1 := First(c.Interval)
+		last, ok2 := Last(c.Interval)
+
+		if first != c.First || last != c.Last || ok1 != c.OK || ok2 != c.OK {
+			t.Fail()
+			t.Logf("Case %v: want (%v, %v, %v), but got (%v, %v, %v, %v)", i, c.First, c.Last, c.OK, first, last, ok1, ok2)
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Set Set[E]
+		Min E
+		Max E
+		OK  bool
+	}{
+		{Set[E]{{1, 3}, {5, 7}}, 1, 6, true},
+		{Set[E]{{4, 5}}, 4, 4, true},
+		{Set[E]{}, 0, 0, false},
+	}
+
+	for i, c := range testCases {
+		lo, ok1 := Min(c.Set)
+		hi, ok2 := Max(c.Set)
+
+		if lo != c.Min || hi != c.Max || ok1 != c.OK || ok2 != c.OK {
+			t.Fail()
+			t.Logf("Case %v: want (%v, %v, %v), but got (%v, %v, %v, %v)", i, c.Min, c.Max, c.OK, lo, hi, ok1, ok2)
+		}
+	}
+}
+
+func TestAscendDescend(t *testing.T) {
+	type E = elems.Int
+
+	s := Set[E]{{1, 3}, {5, 7}}
+
+	testCases := []struct {
+		Actual, Expected []E
+	}{
+		{collect(Ascend[E], s, -1), []E{1, 2, 5, 6}},
+		{collect(Descend[E], s, -1), []E{6, 5, 2, 1}},
+		{collect(Ascend[E], s, 3), []E{1, 2, 5}},
+		{collect(Descend[E], s, 3), []E{6, 5, 2}},
+		{collect(Ascend[E], nil, -1), nil},
+		{collect(Descend[E], nil, -1), nil},
+	}
+
+	for i, c := range testCases {
+		if !equalElems(c.Actual, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+		}
+	}
+}
+
+func collect[E Elem[E]](each func(Set[E], func(E) bool), x Set[E], n int) []E {
+	var s []E
+
+	each(x, func(v E) bool {
+		s = append(s, v)
+		return len(s) != n
+	})
+
+	return s
+}
+
+func equalElems[E Elem[E]](x, y []E) bool {
+	if len(x) != len(y) {
+		return false
+	}
+
+	for i := range x {
+		if x[i].Compare(y[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -45,6 +45,75 @@ func FuzzDelete(f *testing.F) {
 	})
 }
 
+func FuzzTreeSetAdd(f *testing.F) {
+	fuzz(f, func(t *testing.T, x, y Set[elems.Uint8]) {
+		z := NewTreeSet(x)
+
+		for _, r := range y {
+			z.Add(r)
+		}
+
+		if w := plainUnion(x, y); !z.Set().Equal(w) || z.Len() != len(w) {
+			t.Logf("x = %v", x)
+			t.Logf("y = %v", y)
+			t.Logf("x ∪ y = %v", w)
+			t.Logf("x ∪ y = %v (actual)", z.Set())
+			t.Fail()
+		}
+	})
+}
+
+func FuzzTreeSetDelete(f *testing.F) {
+	fuzz(f, func(t *testing.T, x, y Set[elems.Uint8]) {
+		z := NewTreeSet(x)
+
+		for _, r := range y {
+			z.Delete(r)
+		}
+
+		if w := plainDifference(x, y); !z.Set().Equal(w) || z.Len() != len(w) {
+			t.Logf("x = %v", x)
+			t.Logf("y = %v", y)
+			t.Logf("x \\ y = %v", w)
+			t.Logf("x \\ y = %v (actual)", z.Set())
+			t.Fail()
+		}
+	})
+}
+
+func FuzzPersistentSet(f *testing.F) {
+	fuzz(f, func(t *testing.T, x, y Set[elems.Uint8]) {
+		p := NewPersistentSet(x)
+		q := p
+
+		for _, r := range y {
+			q = q.Add(r)
+		}
+
+		testCases := []struct {
+			Op               string
+			Actual, Expected Set[elems.Uint8]
+		}{
+			{"∪", q.Set(), plainUnion(x, y)},
+			{"∪", p.Union(y).Set(), plainUnion(x, y)},
+			{"∩", p.Intersection(y).Set(), plainIntersection(x, y)},
+			{"\\", p.Difference(y).Set(), plainDifference(x, y)},
+			{"△", p.SymmetricDifference(y).Set(), plainSymmetricDifference(x, y)},
+			{"", p.Set(), x},
+		}
+
+		for _, c := range testCases {
+			if !c.Actual.Equal(c.Expected) {
+				t.Logf("x = %v", x)
+				t.Logf("y = %v", y)
+				t.Logf("x %v y = %v", c.Op, c.Expected)
+				t.Logf("x %v y = %v (actual)", c.Op, c.Actual)
+				t.Fail()
+			}
+		}
+	})
+}
+
 func FuzzContains(f *testing.F) {
 	fuzz(f, func(t *testing.T, x, y Set[elems.Uint8]) {
 		yes := true
@@ -225,6 +294,60 @@ func FuzzCollectInto(f *testing.F) {
 	})
 }
 
+func FuzzIntervalTree(f *testing.F) {
+	addRandomSeed(f, 16)
+
+	f.Fuzz(func(
+		t *testing.T,
+		x0, x1, x2, x3, x4, x5, x6, x7, x8, x9, x10, x11, x12, x13, x14, x15 byte,
+	) {
+		xs := []byte{x0, x1, x2, x3, x4, x5, x6, x7, x8, x9, x10, x11, x12, x13, x14, x15}
+
+		var x []Interval[elems.Uint8]
+
+		var tree IntervalTree[elems.Uint8, int]
+
+		for i, j := 0, len(xs); i < j; i += 2 {
+			r := Range(elems.Uint8(xs[i]), elems.Uint8(xs[i+1]))
+			x = append(x, r)
+			tree.Insert(r, i)
+		}
+
+		tree.Remove(x[0], 0)
+
+		for v := 0; v < 256; v++ {
+			var want, got []int
+
+			for i, r := range x[1:] {
+				if r.Low <= elems.Uint8(v) && elems.Uint8(v) < r.High {
+					want = append(want, 2*(i+1))
+				}
+			}
+
+			tree.Stabbing(elems.Uint8(v), func(_ Interval[elems.Uint8], i int) bool {
+				got = append(got, i)
+				return true
+			})
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, want) {
+				t.Logf("x = %v", x)
+				t.Logf("stabbing(%v) = %v", v, want)
+				t.Logf("stabbing(%v) = %v (actual)", v, got)
+				t.Fail()
+			}
+		}
+
+		if w, z := plainUnion(x[1:]), tree.Set(); !z.Equal(w) {
+			t.Logf("x = %v", x)
+			t.Logf("collect(x...) = %v", w)
+			t.Logf("collect(x...) = %v (actual)", z)
+			t.Fail()
+		}
+	})
+}
+
 func plainIsSubsetOf(x, y Set[elems.Uint8]) bool {
 	var universe [256]bool
 
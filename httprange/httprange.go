@@ -0,0 +1,283 @@
+// Package httprange converts between Sets of byte offsets and the Range and
+// Content-Range header fields of HTTP, as specified in RFC 9110.
+//
+// Byte offsets are zero-based. A range "first-last" in a header field, in
+// which last is inclusive, corresponds to the Interval [first, last+1).
+package httprange
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// Set is a set of byte offsets.
+type Set = intervals.Set[elems.Int64]
+
+// Interval is a range of byte offsets.
+type Interval = intervals.Interval[elems.Int64]
+
+var (
+	// ErrInvalid is returned when a header field is malformed or uses a range
+	// unit other than "bytes". A server should ignore an invalid Range header
+	// field and respond with the full representation.
+	ErrInvalid = errors.New("httprange: invalid range")
+
+	// ErrUnsatisfiable is returned when none of the requested ranges overlaps
+	// the representation. A server should respond with status code 416
+	// (Range Not Satisfiable).
+	ErrUnsatisfiable = errors.New("httprange: range not satisfiable")
+
+	// ErrOverlapping is returned when more than two of the requested ranges
+	// overlap one another, i.e. have a byte in common. RFC 9110 allows
+	// a server to reject such a request, either by ignoring the Range header
+	// field or by responding with status code 416.
+	ErrOverlapping = errors.New("httprange: overlapping ranges")
+)
+
+// ParseRange parses the value of a Range header field, for example,
+// "bytes=0-99,200-,-500", against a representation of size bytes.
+//
+// Ranges that start at or beyond size are ignored; last positions beyond
+// the end are clipped to size-1; a suffix range larger than size selects
+// the whole representation. ParseRange returns ErrUnsatisfiable if no range
+// remains, and ErrOverlapping if more than two of the remaining ranges have
+// a byte in common. Otherwise, overlapping and adjacent ranges are coalesced
+// in the returned Set.
+func ParseRange(s string, size int64) (Set, error) {
+	unit, spec, ok := strings.Cut(s, "=")
+	if !ok || !strings.EqualFold(strings.TrimRight(unit, " \t"), "bytes") {
+		return nil, ErrInvalid
+	}
+
+	var rs []Interval
+
+	n := 0
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.Trim(part, " \t")
+		if part == "" {
+			continue
+		}
+
+		r, err := parseRangeSpec(part, size)
+		if err != nil {
+			return nil, err
+		}
+
+		n++
+
+		if r.Low.Compare(r.High) >= 0 {
+			continue // Unsatisfiable.
+		}
+
+		rs = append(rs, r)
+	}
+
+	switch {
+	case n == 0:
+		return nil, ErrInvalid
+	case len(rs) == 0:
+		return nil, ErrUnsatisfiable
+	}
+
+	slices.SortFunc(rs, func(a, b Interval) int { return a.Low.Compare(b.Low) })
+
+	if maxDepth(rs) > 2 {
+		return nil, ErrOverlapping
+	}
+
+	return intervals.Collect(rs...), nil
+}
+
+// maxDepth returns the maximum number of ranges in rs that contain a common
+// byte.
+func maxDepth(rs []Interval) int {
+	ends := make([]elems.Int64, len(rs))
+	for i, r := range rs {
+		ends[i] = r.High
+	}
+
+	slices.Sort(ends)
+
+	depth, j := 0, 0
+
+	// rs are sorted by Low. When rs[i] starts, ranges that have not ended
+	// yet contain rs[i].Low.
+	for i, r := range rs {
+		for j < len(ends) && ends[j] <= r.Low {
+			j++
+		}
+
+		depth = max(depth, i+1-j)
+	}
+
+	return depth
+}
+
+// parseRangeSpec parses an int-range or a suffix-range. It returns an empty
+// Interval if the range is unsatisfiable.
+func parseRangeSpec(s string, size int64) (Interval, error) {
+	first, last, ok := strings.Cut(s, "-")
+	if !ok {
+		return Interval{}, ErrInvalid
+	}
+
+	if first == "" {
+		n, ok := parseInt(last)
+		if !ok {
+			return Interval{}, ErrInvalid
+		}
+
+		return intervals.Range(elems.Int64(size-min(n, size)), elems.Int64(size)), nil
+	}
+
+	lo, ok := parseInt(first)
+	if !ok {
+		return Interval{}, ErrInvalid
+	}
+
+	hi := size
+
+	if last != "" {
+		n, ok := parseInt(last)
+		if !ok || n < lo {
+			return Interval{}, ErrInvalid
+		}
+
+		if n < size {
+			hi = n + 1
+		}
+	}
+
+	if lo >= size {
+		return Interval{}, nil
+	}
+
+	return intervals.Range(elems.Int64(lo), elems.Int64(hi)), nil
+}
+
+// parseInt parses a non-empty string of decimal digits. Values that do not
+// fit in an int64 saturate at math.MaxInt64.
+func parseInt(s string) (int64, bool) {
+	if s == "" {
+		return 0, false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		n = 1<<63 - 1
+	}
+
+	return n, true
+}
+
+// FormatRange returns the value of a Range header field that requests
+// the ranges in x, for example, "bytes=0-99,200-299".
+// If x is empty, FormatRange returns an empty string.
+func FormatRange(x Set) string {
+	if len(x) == 0 {
+		return ""
+	}
+
+	b := []byte("bytes=")
+
+	for i, r := range x {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = appendRange(b, r)
+	}
+
+	return string(b)
+}
+
+// FormatContentRange returns the value of a Content-Range header field for
+// the range r in a representation of size bytes, for example,
+// "bytes 0-99/1234". If size is negative, the complete length is formatted
+// as "*", which means that it is unknown.
+//
+// When responding with a multipart/byteranges payload, each part has its own
+// Content-Range header field for one Interval of the selected Set.
+func FormatContentRange(r Interval, size int64) string {
+	b := appendRange([]byte("bytes "), r)
+	b = append(b, '/')
+
+	if size < 0 {
+		b = append(b, '*')
+	} else {
+		b = strconv.AppendInt(b, size, 10)
+	}
+
+	return string(b)
+}
+
+// FormatUnsatisfiedRange returns the value of a Content-Range header field
+// that accompanies a 416 (Range Not Satisfiable) response for
+// a representation of size bytes, for example, "bytes */1234".
+func FormatUnsatisfiedRange(size int64) string {
+	return "bytes */" + strconv.FormatInt(size, 10)
+}
+
+func appendRange(b []byte, r Interval) []byte {
+	b = strconv.AppendInt(b, int64(r.Low), 10)
+	b = append(b, '-')
+	b = strconv.AppendInt(b, int64(r.High)-1, 10)
+
+	return b
+}
+
+// ParseContentRange parses the value of a Content-Range header field, for
+// example, "bytes 0-99/1234", and returns the range and the complete length.
+// If the complete length is "*", size is -1. For an unsatisfied-range, for
+// example, "bytes */1234", r is the zero value.
+func ParseContentRange(s string) (r Interval, size int64, err error) {
+	unit, rest, ok := strings.Cut(s, " ")
+	if !ok || !strings.EqualFold(unit, "bytes") {
+		return Interval{}, 0, ErrInvalid
+	}
+
+	resp, length, ok := strings.Cut(rest, "/")
+	if !ok {
+		return Interval{}, 0, ErrInvalid
+	}
+
+	if length == "*" {
+		size = -1
+	} else if size, ok = parseInt(length); !ok {
+		return Interval{}, 0, ErrInvalid
+	}
+
+	if resp == "*" {
+		if size < 0 {
+			return Interval{}, 0, ErrInvalid
+		}
+
+		return Interval{}, size, nil
+	}
+
+	first, last, ok := strings.Cut(resp, "-")
+	if !ok {
+		return Interval{}, 0, ErrInvalid
+	}
+
+	lo, ok1 := parseInt(first)
+	hi, ok2 := parseInt(last)
+
+	if !ok1 || !ok2 || hi < lo || hi == 1<<63-1 || size >= 0 && hi >= size {
+		return Interval{}, 0, ErrInvalid
+	}
+
+	return intervals.Range(elems.Int64(lo), elems.Int64(hi+1)), size, nil
+}
@@ -0,0 +1,122 @@
+package httprange_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/httprange"
+)
+
+func r(lo, hi int64) httprange.Interval {
+	return intervals.Range(elems.Int64(lo), elems.Int64(hi))
+}
+
+func set(s ...httprange.Interval) httprange.Set {
+	return intervals.Collect(s...)
+}
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		Header   string
+		Size     int64
+		Expected httprange.Set
+		Err      error
+	}{
+		{"bytes=0-99", 1000, set(r(0, 100)), nil},
+		{"bytes=0-99,200-499,-50", 1000, set(r(0, 100), r(200, 500), r(950, 1000)), nil},
+		{"bytes=200-", 1000, set(r(200, 1000)), nil},
+		{"bytes=-500", 1000, set(r(500, 1000)), nil},
+		{"bytes=-5000", 1000, set(r(0, 1000)), nil},
+		{"bytes=500-5000", 1000, set(r(500, 1000)), nil},
+		{"bytes=0-0,-1", 1000, set(r(0, 1), r(999, 1000)), nil},
+		{"bytes=0-9,10-19", 1000, set(r(0, 20)), nil},
+		{"bytes=0-99999999999999999999", 1000, set(r(0, 1000)), nil},
+		{"Bytes = 1-2 , , 5-6 ", 1000, set(r(1, 3), r(5, 7)), nil},
+		{"bytes=0-9,2000-", 1000, set(r(0, 10)), nil},
+		{"bytes=1000-", 1000, nil, httprange.ErrUnsatisfiable},
+		{"bytes=-0", 1000, nil, httprange.ErrUnsatisfiable},
+		{"bytes=0-", 0, nil, httprange.ErrUnsatisfiable},
+		{"bytes=0-99,200-,-500", 300, set(r(0, 300)), nil},
+		{"bytes=0-99,200-,-500", 1000, set(r(0, 100), r(200, 1000)), nil},
+		{"bytes=0-99,200-,-500", 10000, set(r(0, 100), r(200, 10000)), nil},
+		{"bytes=0-9,5-14", 1000, set(r(0, 15)), nil},
+		{"bytes=0-99,-950", 1000, set(r(0, 1000)), nil},
+		{"bytes=0-9,5-14,20-29,25-34", 1000, set(r(0, 15), r(20, 35)), nil},
+		{"bytes=0-99,10-19,50-59", 1000, set(r(0, 100)), nil},
+		{"bytes=0-9,5-14,8-19", 1000, nil, httprange.ErrOverlapping},
+		{"bytes=0-99,10-59,50-59", 1000, nil, httprange.ErrOverlapping},
+		{"bytes=-10,0-,990-", 1000, nil, httprange.ErrOverlapping},
+		{"bytes=0-9,10-19,20-29", 1000, set(r(0, 30)), nil},
+		{"bytes=9-0", 1000, nil, httprange.ErrInvalid},
+		{"bytes=", 1000, nil, httprange.ErrInvalid},
+		{"bytes=,", 1000, nil, httprange.ErrInvalid},
+		{"bytes=1", 1000, nil, httprange.ErrInvalid},
+		{"bytes=-", 1000, nil, httprange.ErrInvalid},
+		{"bytes=+1-2", 1000, nil, httprange.ErrInvalid},
+		{"bytes=0-9,x", 1000, nil, httprange.ErrInvalid},
+		{"items=0-9", 1000, nil, httprange.ErrInvalid},
+		{"0-9", 1000, nil, httprange.ErrInvalid},
+	}
+
+	for i, c := range testCases {
+		x, err := httprange.ParseRange(c.Header, c.Size)
+		if !errors.Is(err, c.Err) || !x.Equal(c.Expected) {
+			t.Logf("Case %v: want %v, %v, but got %v, %v", i, c.Expected, c.Err, x, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	testCases := []struct {
+		Actual, Expected string
+	}{
+		{httprange.FormatRange(nil), ""},
+		{httprange.FormatRange(set(r(0, 100))), "bytes=0-99"},
+		{httprange.FormatRange(set(r(0, 1), r(200, 300))), "bytes=0-0,200-299"},
+		{httprange.FormatContentRange(r(0, 100), 1234), "bytes 0-99/1234"},
+		{httprange.FormatContentRange(r(5, 6), -1), "bytes 5-5/*"},
+		{httprange.FormatUnsatisfiedRange(1234), "bytes */1234"},
+	}
+
+	for i, c := range testCases {
+		if c.Actual != c.Expected {
+			t.Logf("Case %v: want %q, but got %q", i, c.Expected, c.Actual)
+			t.Fail()
+		}
+	}
+
+	x := set(r(0, 10), r(20, 30), r(95, 100))
+	if y, err := httprange.ParseRange(httprange.FormatRange(x), 100); err != nil || !y.Equal(x) {
+		t.Fatalf("round trip: want %v, but got %v, %v", x, y, err)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	testCases := []struct {
+		Header   string
+		Expected httprange.Interval
+		Size     int64
+		Err      error
+	}{
+		{"bytes 0-99/1234", r(0, 100), 1234, nil},
+		{"bytes 5-5/*", r(5, 6), -1, nil},
+		{"bytes */1234", httprange.Interval{}, 1234, nil},
+		{"bytes 0-1234/1234", httprange.Interval{}, 0, httprange.ErrInvalid},
+		{"bytes 9-0/1234", httprange.Interval{}, 0, httprange.ErrInvalid},
+		{"bytes */*", httprange.Interval{}, 0, httprange.ErrInvalid},
+		{"bytes 0-99", httprange.Interval{}, 0, httprange.ErrInvalid},
+		{"bytes 0/1234", httprange.Interval{}, 0, httprange.ErrInvalid},
+		{"items 0-99/1234", httprange.Interval{}, 0, httprange.ErrInvalid},
+	}
+
+	for i, c := range testCases {
+		rr, size, err := httprange.ParseContentRange(c.Header)
+		if !errors.Is(err, c.Err) || !rr.Equal(c.Expected) || size != c.Size {
+			t.Logf("Case %v: want %v, %v, %v, but got %v, %v, %v", i, c.Expected, c.Size, c.Err, rr, size, err)
+			t.Fail()
+		}
+	}
+}
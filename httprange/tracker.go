@@ -0,0 +1,130 @@
+package httprange
+
+import (
+	"sync"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// A ChunkTracker tracks which bytes of a representation of a known size have
+// been received, for example, by a downloader that fetches ranges in
+// parallel or resumes a partial download.
+//
+// A ChunkTracker is safe for concurrent use by multiple goroutines.
+type ChunkTracker struct {
+	mu       sync.Mutex
+	size     int64
+	received Set
+}
+
+// NewChunkTracker returns a ChunkTracker for a representation of size bytes.
+func NewChunkTracker(size int64) *ChunkTracker {
+	return &ChunkTracker{size: max(size, 0)}
+}
+
+// Size returns the size of the representation.
+func (t *ChunkTracker) Size() int64 {
+	return t.size
+}
+
+// MarkReceived records that bytes in r have been received.
+// Bytes outside of [0, t.Size()) are ignored.
+func (t *ChunkTracker) MarkReceived(r Interval) {
+	r.Low = max(r.Low, 0)
+	r.High = min(r.High, elems.Int64(t.size))
+
+	if r.Low.Compare(r.High) >= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	t.received = intervals.Add(t.received, r)
+	t.mu.Unlock()
+}
+
+// Received returns the set of bytes that have been received.
+func (t *ChunkTracker) Received() Set {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append(Set(nil), t.received...)
+}
+
+// Missing returns the set of bytes that have not been received yet.
+// FormatRange(t.Missing()) requests all of them.
+func (t *ChunkTracker) Missing() Set {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.whole().Difference(t.received)
+}
+
+// NextMissing returns the first range of missing bytes, which is at most
+// maxLen bytes long. If maxLen <= 0, the length is not limited.
+// NextMissing reports false if all bytes have been received.
+//
+// NextMissing does not reserve the returned range; callers that dispatch
+// work to multiple goroutines should keep track of dispatched ranges
+// themselves.
+func (t *ChunkTracker) NextMissing(maxLen int64) (Interval, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lo := elems.Int64(0)
+	if len(t.received) != 0 && t.received[0].Low == 0 {
+		lo = t.received[0].High
+	}
+
+	if lo == elems.Int64(t.size) {
+		return Interval{}, false
+	}
+
+	hi := elems.Int64(t.size)
+	if len(t.received) != 0 && t.received[0].Low != 0 {
+		hi = t.received[0].Low
+	} else if len(t.received) > 1 {
+		hi = t.received[1].Low
+	}
+
+	if maxLen > 0 && int64(hi-lo) > maxLen {
+		hi = lo + elems.Int64(maxLen)
+	}
+
+	return intervals.Range(lo, hi), true
+}
+
+// Complete reports whether all bytes have been received.
+func (t *ChunkTracker) Complete() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.received.Equal(t.whole())
+}
+
+// ReceivedBytes returns the number of bytes that have been received.
+func (t *ChunkTracker) ReceivedBytes() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var n int64
+	for _, r := range t.received {
+		n += int64(r.High - r.Low)
+	}
+
+	return n
+}
+
+// Progress returns the percentage of bytes that have been received,
+// ranging from 0 to 100. If t.Size() is 0, Progress returns 100.
+func (t *ChunkTracker) Progress() float64 {
+	if t.size == 0 {
+		return 100
+	}
+
+	return float64(t.ReceivedBytes()) * 100 / float64(t.size)
+}
+
+func (t *ChunkTracker) whole() Set {
+	return intervals.Range(0, elems.Int64(t.size)).Set()
+}
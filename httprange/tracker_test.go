@@ -0,0 +1,102 @@
+package httprange_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/b97tsk/intervals/httprange"
+)
+
+func TestChunkTracker(t *testing.T) {
+	tr := httprange.NewChunkTracker(100)
+
+	next := func(maxLen int64) httprange.Interval {
+		r, ok := tr.NextMissing(maxLen)
+		if !ok {
+			return httprange.Interval{}
+		}
+
+		return r
+	}
+
+	assertions := []bool{
+		tr.Size() == 100,
+		tr.Progress() == 0,
+		!tr.Complete(),
+		tr.Missing().Equal(set(r(0, 100))),
+		next(0).Equal(r(0, 100)),
+		next(30).Equal(r(0, 30)),
+	}
+
+	tr.MarkReceived(r(10, 20))
+	tr.MarkReceived(r(90, 200))
+	tr.MarkReceived(r(-5, 0))
+
+	assertions = append(assertions,
+		tr.Received().Equal(set(r(10, 20), r(90, 100))),
+		tr.Missing().Equal(set(r(0, 10), r(20, 90))),
+		tr.ReceivedBytes() == 20,
+		tr.Progress() == 20,
+		next(0).Equal(r(0, 10)),
+		httprange.FormatRange(tr.Missing()) == "bytes=0-9,20-89",
+	)
+
+	tr.MarkReceived(r(0, 10))
+
+	assertions = append(assertions,
+		next(0).Equal(r(20, 90)),
+		next(50).Equal(r(20, 70)),
+	)
+
+	tr.MarkReceived(r(20, 90))
+
+	_, ok := tr.NextMissing(0)
+
+	assertions = append(assertions,
+		!ok,
+		tr.Complete(),
+		tr.Progress() == 100,
+		len(tr.Missing()) == 0,
+	)
+
+	empty := httprange.NewChunkTracker(0)
+
+	assertions = append(assertions,
+		empty.Complete(),
+		empty.Progress() == 100,
+	)
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Logf("Case %v: FAILED", i)
+			t.Fail()
+		}
+	}
+}
+
+func TestChunkTrackerConcurrent(t *testing.T) {
+	const size, chunk = 1 << 16, 100
+
+	tr := httprange.NewChunkTracker(size)
+
+	var wg sync.WaitGroup
+
+	for w := int64(0); w < 8; w++ {
+		wg.Add(1)
+
+		go func(w int64) {
+			defer wg.Done()
+
+			for lo := w * chunk; lo < size; lo += 8 * chunk {
+				tr.MarkReceived(r(lo, lo+chunk))
+				tr.Missing()
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	if !tr.Complete() {
+		t.Fatalf("want complete, but missing %v", tr.Missing())
+	}
+}
@@ -0,0 +1,112 @@
+package intervals
+
+// In the following methods, an Interval r with r.Low.Compare(r.High) >= 0,
+// which is either empty or invalid, is treated as having no element, and
+// an Interval that has no element is returned as the zero value.
+
+// Intersect returns the Interval of elements that are in both r and s.
+func (r Interval[E]) Intersect(s Interval[E]) Interval[E] {
+	if r.Low.Compare(s.Low) < 0 {
+		r.Low = s.Low
+	}
+
+	if r.High.Compare(s.High) > 0 {
+		r.High = s.High
+	}
+
+	return r.orZero()
+}
+
+// Overlaps reports whether r and s have any element in common.
+// Unlike the Allen relation RelOverlaps, Overlaps is true whenever
+// r.Relation(s) is neither RelBefore, RelMeets, RelMetBy nor RelAfter.
+func (r Interval[E]) Overlaps(s Interval[E]) bool {
+	return r.Low.Compare(s.High) < 0 && s.Low.Compare(r.High) < 0 &&
+		r.Low.Compare(r.High) < 0 && s.Low.Compare(s.High) < 0
+}
+
+// Contains reports whether r contains every element in s.
+// If s has no element, Contains reports false.
+// Unlike the Allen relation RelContains, Contains is also true when
+// r.Relation(s) is RelEquals, RelStartedBy or RelFinishedBy.
+func (r Interval[E]) Contains(s Interval[E]) bool {
+	return r.Low.Compare(s.Low) <= 0 && s.High.Compare(r.High) <= 0 && s.Low.Compare(s.High) < 0
+}
+
+// ContainsUnit reports whether r contains a single element v.
+func (r Interval[E]) ContainsUnit(v E) bool {
+	return r.Low.Compare(v) <= 0 && v.Compare(r.High) < 0
+}
+
+// Hull returns the smallest Interval that contains every element in r and s.
+func (r Interval[E]) Hull(s Interval[E]) Interval[E] {
+	switch {
+	case s.Low.Compare(s.High) >= 0:
+		return r.orZero()
+	case r.Low.Compare(r.High) >= 0:
+		return s
+	}
+
+	if s.Low.Compare(r.Low) < 0 {
+		r.Low = s.Low
+	}
+
+	if s.High.Compare(r.High) > 0 {
+		r.High = s.High
+	}
+
+	return r
+}
+
+// Gap returns the Interval of elements that lie between r and s, which is
+// the zero value if r and s overlap or are adjacent.
+func (r Interval[E]) Gap(s Interval[E]) Interval[E] {
+	if r.Low.Compare(r.High) >= 0 || s.Low.Compare(s.High) >= 0 {
+		return Interval[E]{}
+	}
+
+	if s.Low.Compare(r.Low) < 0 {
+		r, s = s, r
+	}
+
+	return Range(r.High, s.Low).orZero()
+}
+
+// Adjacent reports whether r and s have no element in common, and there is
+// no element between them, i.e. r.High equals s.Low or s.High equals r.Low.
+func (r Interval[E]) Adjacent(s Interval[E]) bool {
+	return (r.High.Compare(s.Low) == 0 || s.High.Compare(r.Low) == 0) &&
+		r.Low.Compare(r.High) < 0 && s.Low.Compare(s.High) < 0
+}
+
+// Subtract returns the elements that are in r, but not in s, as up to two
+// Intervals: the part of r before s and the part of r after s.
+// Either of them is the zero value if it has no element.
+func (r Interval[E]) Subtract(s Interval[E]) (before, after Interval[E]) {
+	if r.Low.Compare(r.High) >= 0 {
+		return Interval[E]{}, Interval[E]{}
+	}
+
+	if s.Low.Compare(s.High) >= 0 || s.High.Compare(r.Low) <= 0 {
+		return Interval[E]{}, r
+	}
+
+	if r.High.Compare(s.Low) <= 0 {
+		return r, Interval[E]{}
+	}
+
+	before, after = r, r
+	before.High = s.Low
+	after.Low = s.High
+
+	return before.orZero(), after.orZero()
+}
+
+// orZero returns r if r has any element, or the zero value otherwise.
+func (r Interval[E]) orZero() Interval[E] {
+	if r.Low.Compare(r.High) < 0 {
+		return r
+	}
+
+	return Interval[E]{}
+}
@@ -0,0 +1,203 @@
+package intervals_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestIntervalMethods(t *testing.T) {
+	type E = elems.Int
+
+	r := Interval[E]{3, 7}
+
+	assertions := []bool{
+		r.Intersect(Interval[E]{5, 9}).Equal(Interval[E]{5, 7}),
+		r.Intersect(Interval[E]{7, 9}).Equal(Interval[E]{}),
+		r.Intersect(Interval[E]{}).Equal(Interval[E]{}),
+		r.Overlaps(Interval[E]{6, 9}),
+		!r.Overlaps(Interval[E]{7, 9}),
+		!r.Overlaps(Interval[E]{5, 5}),
+		r.Contains(Interval[E]{3, 7}),
+		r.Contains(Interval[E]{4, 5}),
+		!r.Contains(Interval[E]{4, 8}),
+		!r.Contains(Interval[E]{5, 5}),
+		r.ContainsUnit(3),
+		!r.ContainsUnit(7),
+		r.Hull(Interval[E]{9, 11}).Equal(Interval[E]{3, 11}),
+		r.Hull(Interval[E]{}).Equal(r),
+		Interval[E]{}.Hull(r).Equal(r),
+		Interval[E]{5, 1}.Hull(Interval[E]{}).Equal(Interval[E]{}),
+		r.Gap(Interval[E]{9, 11}).Equal(Interval[E]{7, 9}),
+		Interval[E]{9, 11}.Gap(r).Equal(Interval[E]{7, 9}),
+		r.Gap(Interval[E]{7, 11}).Equal(Interval[E]{}),
+		r.Gap(Interval[E]{5, 11}).Equal(Interval[E]{}),
+		r.Adjacent(Interval[E]{7, 9}),
+		r.Adjacent(Interval[E]{1, 3}),
+		!r.Adjacent(Interval[E]{6, 9}),
+		!r.Adjacent(Interval[E]{7, 7}),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+
+	testCases := []struct {
+		S             Interval[E]
+		Before, After Interval[E]
+	}{
+		{Interval[E]{4, 5}, Interval[E]{3, 4}, Interval[E]{5, 7}},
+		{Interval[E]{1, 5}, Interval[E]{}, Interval[E]{5, 7}},
+		{Interval[E]{5, 9}, Interval[E]{3, 5}, Interval[E]{}},
+		{Interval[E]{1, 9}, Interval[E]{}, Interval[E]{}},
+		{Interval[E]{1, 3}, Interval[E]{}, Interval[E]{3, 7}},
+		{Interval[E]{7, 9}, Interval[E]{3, 7}, Interval[E]{}},
+		{Interval[E]{}, Interval[E]{}, Interval[E]{3, 7}},
+	}
+
+	for i, c := range testCases {
+		before, after := r.Subtract(c.S)
+		if !before.Equal(c.Before) || !after.Equal(c.After) {
+			t.Logf("Case %v: want %v %v, but got %v %v", i, c.Before, c.After, before, after)
+			t.Fail()
+		}
+	}
+}
+
+func TestIntervalMethodsExhaustive(t *testing.T) {
+	type E = elems.Int
+
+	const n = 6
+
+	var all []Interval[E]
+
+	for lo := E(0); lo < n; lo++ {
+		for hi := E(0); hi < n; hi++ {
+			all = append(all, Interval[E]{lo, hi})
+		}
+	}
+
+	set := func(r Interval[E]) Set[E] {
+		if r.Low >= r.High {
+			return nil
+		}
+
+		return r.Set()
+	}
+
+	for _, r := range all {
+		for _, s := range all {
+			x, y := set(r), set(s)
+
+			if got := r.Intersect(s); !got.Equal(x.Intersection(y).Extent()) {
+				t.Fatalf("%v.Intersect(%v) = %v", r, s, got)
+			}
+
+			if got := r.Overlaps(s); got != x.Overlaps(y) {
+				t.Fatalf("%v.Overlaps(%v) = %v", r, s, got)
+			}
+
+			if got := r.Contains(s); got != x.Contains(s) {
+				t.Fatalf("%v.Contains(%v) = %v", r, s, got)
+			}
+
+			if got := r.Hull(s); !got.Equal(x.Union(y).Extent()) {
+				t.Fatalf("%v.Hull(%v) = %v", r, s, got)
+			}
+
+			gap := Interval[E]{}
+			if len(x) != 0 && len(y) != 0 && !x.Overlaps(y) {
+				gap = x.Union(y).Extent().Set().Difference(x.Union(y)).Extent()
+			}
+
+			if got := r.Gap(s); !got.Equal(gap) {
+				t.Fatalf("%v.Gap(%v) = %v, want %v", r, s, got, gap)
+			}
+
+			if got := r.Adjacent(s); got != (len(x) != 0 && len(y) != 0 && !x.Overlaps(y) && len(x.Union(y)) == 1) {
+				t.Fatalf("%v.Adjacent(%v) = %v", r, s, got)
+			}
+
+			before, after := r.Subtract(s)
+			if !Collect(before, after).Equal(x.Difference(y)) || (before != Interval[E]{} && after != Interval[E]{} && !before.Before(after)) {
+				t.Fatalf("%v.Subtract(%v) = %v, %v", r, s, before, after)
+			}
+
+			for v := E(0); v < n; v++ {
+				if r.ContainsUnit(v) != x.ContainsUnit(v) {
+					t.Fatalf("%v.ContainsUnit(%v) = %v", r, v, r.ContainsUnit(v))
+				}
+			}
+		}
+	}
+}
+
+func TestIntervalMethodsAllocs(t *testing.T) {
+	type E = elems.Int
+
+	r, s := Interval[E]{3, 7}, Interval[E]{5, 9}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = r.Intersect(s)
+		_ = r.Overlaps(s)
+		_ = r.Contains(s)
+		_ = r.ContainsUnit(5)
+		_ = r.Hull(s)
+		_ = r.Gap(s)
+		_ = r.Adjacent(s)
+		_, _ = r.Subtract(s)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("want no allocations, but got %v", allocs)
+	}
+}
+
+func TestIntervalMethodsVersusRelation(t *testing.T) {
+	type E = elems.Int
+
+	const n = 6
+
+	for lo := E(0); lo < n; lo++ {
+		for hi := lo + 1; hi < n; hi++ {
+			for lo2 := E(0); lo2 < n; lo2++ {
+				for hi2 := lo2 + 1; hi2 < n; hi2++ {
+					r, s := Interval[E]{lo, hi}, Interval[E]{lo2, hi2}
+
+					rel := r.Relation(s)
+					overlaps := rel != RelBefore && rel != RelMeets && rel != RelMetBy && rel != RelAfter
+					contains := rel == RelEquals || rel == RelStartedBy || rel == RelFinishedBy || rel == RelContains
+
+					if r.Overlaps(s) != overlaps {
+						t.Fatalf("%v.Overlaps(%v) = %v, but relation is %v", r, s, r.Overlaps(s), rel)
+					}
+
+					if r.Contains(s) != contains {
+						t.Fatalf("%v.Contains(%v) = %v, but relation is %v", r, s, r.Contains(s), rel)
+					}
+				}
+			}
+		}
+	}
+
+	r := Interval[E]{1, 5}
+
+	assertions := []bool{
+		r.Contains(r) && r.Relation(r) == RelEquals,
+		r.Contains(Interval[E]{1, 3}) && r.Relation(Interval[E]{1, 3}) == RelStartedBy,
+		r.Contains(Interval[E]{3, 5}) && r.Relation(Interval[E]{3, 5}) == RelFinishedBy,
+		r.Overlaps(Interval[E]{2, 3}) && r.Relation(Interval[E]{2, 3}) == RelContains,
+		r.Overlaps(Interval[E]{0, 9}) && r.Relation(Interval[E]{0, 9}) == RelDuring,
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
@@ -19,6 +19,14 @@ type Enum[E any] interface {
 	Next() E
 }
 
+// A BiEnum is an Enum that can also be enumerated in decreasing ordering.
+// For any element v, v.Next().Prev() and v.Prev().Next() should equal to v,
+// unless there is no element next to or previous to v.
+type BiEnum[E any] interface {
+	Enum[E]
+	Prev() E
+}
+
 // An Interval is a half-open continuous range of elements.
 type Interval[E Elem[E]] struct {
 	Low  E // inclusive
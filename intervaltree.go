@@ -0,0 +1,226 @@
+package intervals
+
+import "cmp"
+
+// An IntervalTree is a collection of possibly overlapping intervals, each
+// associated with a value of type V. Unlike a Set, an IntervalTree keeps
+// every interval as inserted, so that one can query which intervals contain
+// a given element or overlap a given range.
+//
+// IntervalTree is implemented as a treap ordered by Low, augmented with the
+// maximum High of every subtree. Insert and Remove run in O(log n) expected
+// time; Stabbing and Overlapping run in O(log n + k) expected time, where k
+// is the number of reported intervals.
+//
+// The zero value for an IntervalTree is an empty tree ready to use.
+// An IntervalTree must not be copied after first use.
+type IntervalTree[E Elem[E], V comparable] struct {
+	root *intervalTreeNode[E, V]
+	len  int
+	seq  uint64
+	seed prng
+}
+
+type intervalTreeNode[E Elem[E], V comparable] struct {
+	r           Interval[E]
+	v           V
+	seq         uint64 // Breaks ties between identical intervals.
+	prio        uint64
+	max         E // The maximum High in this subtree.
+	left, right *intervalTreeNode[E, V]
+}
+
+func (n *intervalTreeNode[E, V]) fix() *intervalTreeNode[E, V] {
+	n.max = n.r.High
+
+	if n.left != nil && n.left.max.Compare(n.max) > 0 {
+		n.max = n.left.max
+	}
+
+	if n.right != nil && n.right.max.Compare(n.max) > 0 {
+		n.max = n.right.max
+	}
+
+	return n
+}
+
+// compare compares n with interval r and sequence number seq.
+func (n *intervalTreeNode[E, V]) compare(r Interval[E], seq uint64) int {
+	if c := compareInterval(n.r, r); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(n.seq, seq)
+}
+
+// compareInterval compares r1 with r2 by Low, then by High.
+func compareInterval[E Elem[E]](r1, r2 Interval[E]) int {
+	if c := r1.Low.Compare(r2.Low); c != 0 {
+		return c
+	}
+
+	return r1.High.Compare(r2.High)
+}
+
+// Len returns the number of intervals in t.
+func (t *IntervalTree[E, V]) Len() int {
+	return t.len
+}
+
+// Insert inserts interval r, associated with value v, into t.
+// Intervals that contain no elements are also inserted, but they are never
+// reported by Stabbing or Overlapping.
+func (t *IntervalTree[E, V]) Insert(r Interval[E], v V) {
+	t.seq++
+	n := &intervalTreeNode[E, V]{r: r, v: v, seq: t.seq, prio: t.seed.next()}
+	l, g := splitIntervalTree(t.root, func(m *intervalTreeNode[E, V]) bool { return m.compare(r, n.seq) < 0 })
+	t.root = mergeIntervalTree(mergeIntervalTree(l, n.fix()), g)
+	t.len++
+}
+
+// Remove removes an interval, which equals to r and is associated with
+// a value that equals to v, from t, and reports whether there was one.
+// If there are more than one, Remove removes the earliest inserted one.
+func (t *IntervalTree[E, V]) Remove(r Interval[E], v V) bool {
+	n := t.root.find(r, v)
+	if n == nil {
+		return false
+	}
+
+	seq := n.seq
+	l, m := splitIntervalTree(t.root, func(m *intervalTreeNode[E, V]) bool { return m.compare(r, seq) < 0 })
+	_, g := splitIntervalTree(m, func(m *intervalTreeNode[E, V]) bool { return m.compare(r, seq) <= 0 })
+	t.root = mergeIntervalTree(l, g)
+	t.len--
+
+	return true
+}
+
+// find returns the earliest inserted node that has interval r and value v,
+// or nil if there is no such node.
+func (n *intervalTreeNode[E, V]) find(r Interval[E], v V) *intervalTreeNode[E, V] {
+	for n != nil {
+		switch c := compareInterval(n.r, r); {
+		case c < 0:
+			n = n.right
+		case c > 0:
+			n = n.left
+		default:
+			if m := n.left.find(r, v); m != nil {
+				return m
+			}
+
+			if n.v == v {
+				return n
+			}
+
+			n = n.right
+		}
+	}
+
+	return nil
+}
+
+// Stabbing calls yield for each interval in t that contains element v,
+// along with its associated value, in ascending order by Low, until yield
+// returns false.
+func (t *IntervalTree[E, V]) Stabbing(v E, yield func(Interval[E], V) bool) {
+	t.root.overlapping(v, v, true, yield)
+}
+
+// Overlapping calls yield for each interval in t that overlaps r, i.e.,
+// has at least one element in common with r, along with its associated
+// value, in ascending order by Low, until yield returns false.
+func (t *IntervalTree[E, V]) Overlapping(r Interval[E], yield func(Interval[E], V) bool) {
+	if r.Low.Compare(r.High) < 0 {
+		t.root.overlapping(r.Low, r.High, false, yield)
+	}
+}
+
+// overlapping calls yield for each node in n whose interval contains
+// element lo (if unit is true), or overlaps range [lo, hi) (if unit is
+// false), until yield returns false.
+func (n *intervalTreeNode[E, V]) overlapping(lo, hi E, unit bool, yield func(Interval[E], V) bool) bool {
+	for n != nil && n.max.Compare(lo) > 0 {
+		if !n.left.overlapping(lo, hi, unit, yield) {
+			return false
+		}
+
+		if c := n.r.Low.Compare(hi); c > 0 || c == 0 && !unit {
+			break
+		}
+
+		if n.r.High.Compare(lo) > 0 && n.r.Low.Compare(n.r.High) < 0 && !yield(n.r, n.v) {
+			return false
+		}
+
+		n = n.right
+	}
+
+	return true
+}
+
+// All calls yield for each interval in t, along with its associated value,
+// in ascending order by Low, until yield returns false.
+func (t *IntervalTree[E, V]) All(yield func(Interval[E], V) bool) {
+	t.root.walk(yield)
+}
+
+func (n *intervalTreeNode[E, V]) walk(yield func(Interval[E], V) bool) bool {
+	for n != nil {
+		if !n.left.walk(yield) || !yield(n.r, n.v) {
+			return false
+		}
+
+		n = n.right
+	}
+
+	return true
+}
+
+// Set returns the set of elements that are in any interval in t.
+func (t *IntervalTree[E, V]) Set() Set[E] {
+	s := make([]Interval[E], 0, t.len)
+
+	t.root.walk(func(r Interval[E], _ V) bool {
+		s = append(s, r)
+		return true
+	})
+
+	return CollectInto(s, s...)
+}
+
+// splitIntervalTree splits n into two trees, the first one contains nodes
+// that satisfy pred, the second one contains the rest.
+// pred must be true for a prefix of the nodes in n and false after.
+func splitIntervalTree[E Elem[E], V comparable](n *intervalTreeNode[E, V], pred func(*intervalTreeNode[E, V]) bool) (l, r *intervalTreeNode[E, V]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if pred(n) {
+		n.right, r = splitIntervalTree(n.right, pred)
+		return n.fix(), r
+	}
+
+	l, n.left = splitIntervalTree(n.left, pred)
+
+	return l, n.fix()
+}
+
+// mergeIntervalTree merges two trees into one. Nodes in l must all precede
+// those in r.
+func mergeIntervalTree[E Elem[E], V comparable](l, r *intervalTreeNode[E, V]) *intervalTreeNode[E, V] {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.prio > r.prio:
+		l.right = mergeIntervalTree(l.right, r)
+		return l.fix()
+	default:
+		r.left = mergeIntervalTree(l, r.left)
+		return r.fix()
+	}
+}
@@ -0,0 +1,111 @@
+package intervals_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestIntervalTree(t *testing.T) {
+	type E = elems.Int
+
+	type Item struct {
+		Interval Interval[E]
+		ID       string
+	}
+
+	var tree IntervalTree[E, string]
+
+	tree.Insert(Range[E](1, 5), "a")
+	tree.Insert(Range[E](3, 9), "b")
+	tree.Insert(Range[E](3, 9), "c")
+	tree.Insert(Range[E](7, 11), "d")
+	tree.Insert(Range[E](13, 15), "e")
+	tree.Insert(Range[E](6, 6), "f")
+	tree.Insert(Range[E](3, 9), "b")
+
+	collect := func(each func(func(Interval[E], string) bool), n int) []Item {
+		var s []Item
+
+		each(func(r Interval[E], id string) bool {
+			s = append(s, Item{r, id})
+			return len(s) != n
+		})
+
+		return s
+	}
+
+	stabbing := func(v E) func(func(Interval[E], string) bool) {
+		return func(yield func(Interval[E], string) bool) { tree.Stabbing(v, yield) }
+	}
+
+	overlapping := func(r Interval[E]) func(func(Interval[E], string) bool) {
+		return func(yield func(Interval[E], string) bool) { tree.Overlapping(r, yield) }
+	}
+
+	testCases := []struct {
+		Actual, Expected []Item
+	}{
+		{collect(stabbing(0), -1), nil},
+		{collect(stabbing(1), -1), []Item{{Range[E](1, 5), "a"}}},
+		{collect(stabbing(4), -1), []Item{{Range[E](1, 5), "a"}, {Range[E](3, 9), "b"}, {Range[E](3, 9), "c"}, {Range[E](3, 9), "b"}}},
+		{collect(stabbing(5), -1), []Item{{Range[E](3, 9), "b"}, {Range[E](3, 9), "c"}, {Range[E](3, 9), "b"}}},
+		{collect(stabbing(9), -1), []Item{{Range[E](7, 11), "d"}}},
+		{collect(stabbing(11), -1), nil},
+		{collect(stabbing(4), 2), []Item{{Range[E](1, 5), "a"}, {Range[E](3, 9), "b"}}},
+		{collect(overlapping(Range[E](9, 13)), -1), []Item{{Range[E](7, 11), "d"}}},
+		{collect(overlapping(Range[E](11, 14)), -1), []Item{{Range[E](13, 15), "e"}}},
+		{collect(overlapping(Range[E](0, 2)), -1), []Item{{Range[E](1, 5), "a"}}},
+		{collect(overlapping(Range[E](5, 7)), -1), []Item{{Range[E](3, 9), "b"}, {Range[E](3, 9), "c"}, {Range[E](3, 9), "b"}}},
+		{collect(overlapping(Range[E](11, 13)), -1), nil},
+		{collect(overlapping(Range[E](5, 5)), -1), nil},
+		{collect(tree.All, 3), []Item{{Range[E](1, 5), "a"}, {Range[E](3, 9), "b"}, {Range[E](3, 9), "c"}}},
+	}
+
+	for i, c := range testCases {
+		if !equalItems(c.Actual, c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+		}
+	}
+
+	if got, want := tree.Set(), (Set[E]{{1, 11}, {13, 15}}); !got.Equal(want) {
+		t.Errorf("Set: want %v, but got %v", want, got)
+	}
+
+	assertions := []bool{
+		tree.Len() == 7,
+		tree.Remove(Range[E](3, 9), "b") == true,
+		tree.Remove(Range[E](3, 9), "d") == false,
+		tree.Remove(Range[E](7, 11), "d") == true,
+		tree.Remove(Range[E](6, 6), "f") == true,
+		tree.Remove(Range[E](6, 6), "f") == false,
+		tree.Len() == 4,
+		equalItems(collect(tree.All, -1), []Item{{Range[E](1, 5), "a"}, {Range[E](3, 9), "c"}, {Range[E](3, 9), "b"}, {Range[E](13, 15), "e"}}),
+		equalItems(collect(stabbing(9), -1), nil),
+		tree.Set().Equal(Set[E]{{1, 9}, {13, 15}}),
+		new(IntervalTree[E, string]).Set().Equal(nil),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func equalItems[T comparable](x, y []T) bool {
+	if len(x) != len(y) {
+		return false
+	}
+
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,125 @@
+package intervals
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// minPartitionSize is the minimum number of intervals, from both inputs,
+// that Parallel hands to a goroutine.
+const minPartitionSize = 1024
+
+// Parallel applies set operation op over x and y, overwriting z, like
+// op(z, x, y) does, but splits the work across up to workers goroutines.
+// If workers <= 0, Parallel uses runtime.GOMAXPROCS(0) goroutines.
+// op can be any of [Difference], [Intersection], [SymmetricDifference] and
+// [Union]. z must not be x or y and z must not be used after.
+//
+// Parallel partitions the range of elements by split points sampled from
+// x and y, then applies op to the intervals that overlap each partition,
+// on separate goroutines, and finally stitches results together. For small
+// inputs, Parallel simply calls op(z, x, y).
+func Parallel[E Elem[E]](op func(z, x, y Set[E]) Set[E], workers int, z, x, y Set[E]) Set[E] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	workers = min(workers, (len(x)+len(y))/minPartitionSize)
+
+	if workers <= 1 {
+		return op(z, x, y)
+	}
+
+	points := splitPoints(x, y, workers)
+	results := make([]Set[E], len(points)+1)
+
+	var wg sync.WaitGroup
+
+	for i := range results {
+		var lo, hi *E
+
+		if i > 0 {
+			lo = &points[i-1]
+		}
+
+		if i < len(points) {
+			hi = &points[i]
+		}
+
+		xi, yi := overlapping(x, lo, hi), overlapping(y, lo, hi)
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i] = clip(op(nil, xi, yi), lo, hi)
+		}(i)
+	}
+
+	wg.Wait()
+
+	z = z[:0]
+
+	for _, s := range results {
+		z = appendIntervals(z, s...)
+	}
+
+	return z
+}
+
+// splitPoints returns up to n-1 elements, in ascending order, that split
+// x and y into n partitions of similar sizes.
+func splitPoints[E Elem[E]](x, y Set[E], n int) []E {
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+
+	points := make([]E, 0, n-1)
+
+	for i := 1; i < n; i++ {
+		v := x[i*len(x)/n].Low
+
+		if k := len(points); k == 0 || points[k-1].Compare(v) < 0 {
+			points = append(points, v)
+		}
+	}
+
+	return points
+}
+
+// overlapping returns intervals in x that overlap range [lo, hi), where
+// a nil lo or hi means the range is unbounded in that direction.
+func overlapping[E Elem[E]](x Set[E], lo, hi *E) Set[E] {
+	if lo != nil {
+		x = x[sort.Search(len(x), func(i int) bool { return x[i].High.Compare(*lo) > 0 }):]
+	}
+
+	if hi != nil {
+		x = x[:sort.Search(len(x), func(i int) bool { return x[i].Low.Compare(*hi) >= 0 })]
+	}
+
+	return x
+}
+
+// clip removes elements outside range [lo, hi) from x, returning the
+// modified Set, where a nil lo or hi means the range is unbounded in that
+// direction.
+func clip[E Elem[E]](x Set[E], lo, hi *E) Set[E] {
+	x = overlapping(x, lo, hi)
+
+	if len(x) == 0 {
+		return nil
+	}
+
+	if r := &x[0]; lo != nil && r.Low.Compare(*lo) < 0 {
+		r.Low = *lo
+	}
+
+	if r := &x[len(x)-1]; hi != nil && r.High.Compare(*hi) > 0 {
+		r.High = *hi
+	}
+
+	return x
+}
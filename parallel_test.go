@@ -0,0 +1,60 @@
+package intervals_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestParallel(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Name string
+		Op   func(z, x, y Set[E]) Set[E]
+	}{
+		{"Difference", Difference[E]},
+		{"Intersection", Intersection[E]},
+		{"SymmetricDifference", SymmetricDifference[E]},
+		{"Union", Union[E]},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+
+	randomSet := func(n int) Set[E] {
+		s := make(Set[E], 0, n)
+		v := E(0)
+
+		for i := 0; i < n; i++ {
+			v += E(1 + rng.Intn(10))
+			lo := v
+			v += E(1 + rng.Intn(10))
+			s = append(s, Range(lo, v))
+		}
+
+		return s
+	}
+
+	sets := [][2]Set[E]{
+		{randomSet(50000), randomSet(50000)},
+		{randomSet(50000), randomSet(100)},
+		{randomSet(100), randomSet(50000)},
+		{randomSet(50000), nil},
+		{randomSet(1000), randomSet(1000)},
+		{nil, nil},
+	}
+
+	for _, c := range testCases {
+		for i, s := range sets {
+			want := c.Op(nil, s[0], s[1])
+
+			for _, workers := range []int{0, 1, 2, 3, 8, 64} {
+				if got := Parallel(c.Op, workers, nil, s[0], s[1]); !got.Equal(want) {
+					t.Errorf("%v: Case %v: workers = %v: results differ", c.Name, i, workers)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package intervals
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Partition assigns each of s to a lane, such that intervals in the same lane
+// are pairwise disjoint, using as few lanes as possible. The number of lanes
+// equals the maximum number of intervals in s that contain a common element.
+// Adjacent intervals, such as [1, 3) and [3, 5), may share a lane.
+//
+// Partition returns the lane of each of s, where lane[i] is the lane of s[i],
+// or -1 if s[i] is empty or invalid, and the set of elements in each lane.
+//
+// Partition runs in O(n log n) time.
+func Partition[E Elem[E]](s []Interval[E]) (lane []int, lanes []Set[E]) {
+	order := make([]int, 0, len(s))
+	lane = make([]int, len(s))
+
+	for i, r := range s {
+		lane[i] = -1
+
+		if r.Low.Compare(r.High) < 0 {
+			order = append(order, i)
+		}
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		return s[i].Low.Compare(s[j].Low)
+	})
+
+	// h holds lanes, ordered by the High of their last intervals.
+	var h laneHeap[E]
+
+	for _, i := range order {
+		r := s[i]
+
+		if len(h) != 0 && h[0].High.Compare(r.Low) <= 0 {
+			k := h[0].lane
+			lane[i] = k
+			lanes[k] = appendInterval(lanes[k], r)
+			h[0].High = r.High
+			heap.Fix(&h, 0)
+
+			continue
+		}
+
+		k := len(lanes)
+		lane[i] = k
+		lanes = append(lanes, Set[E]{r})
+		heap.Push(&h, laneEnd[E]{r.High, k})
+	}
+
+	return lane, lanes
+}
+
+type laneEnd[E Elem[E]] struct {
+	High E
+	lane int
+}
+
+type laneHeap[E Elem[E]] []laneEnd[E]
+
+func (h laneHeap[E]) Len() int           { return len(h) }
+func (h laneHeap[E]) Less(i, j int) bool { return h[i].High.Compare(h[j].High) < 0 }
+func (h laneHeap[E]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *laneHeap[E]) Push(x any) { *h = append(*h, x.(laneEnd[E])) }
+
+func (h *laneHeap[E]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return x
+}
@@ -0,0 +1,92 @@
+package intervals_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestPartition(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Intervals []Interval[E]
+		Lane      []int
+		Lanes     []Set[E]
+	}{
+		{nil, []int{}, nil},
+		{[]Interval[E]{{1, 3}}, []int{0}, []Set[E]{{{1, 3}}}},
+		{[]Interval[E]{{1, 3}, {3, 5}}, []int{0, 0}, []Set[E]{{{1, 5}}}},
+		{[]Interval[E]{{1, 4}, {3, 5}}, []int{0, 1}, []Set[E]{{{1, 4}}, {{3, 5}}}},
+		{
+			[]Interval[E]{{1, 5}, {2, 4}, {6, 8}, {4, 7}, {3, 9}, {5, 6}},
+			[]int{0, 1, 0, 1, 2, 0},
+			[]Set[E]{{{1, 8}}, {{2, 7}}, {{3, 9}}},
+		},
+		{[]Interval[E]{{5, 5}, {3, 1}, {1, 2}}, []int{-1, -1, 0}, []Set[E]{{{1, 2}}}},
+	}
+
+	for i, c := range testCases {
+		lane, lanes := Partition(c.Intervals)
+		if !slices.Equal(lane, c.Lane) || !slices.EqualFunc(lanes, c.Lanes, Set[E].Equal) {
+			t.Logf("Case %v: want %v %v, but got %v %v", i, c.Lane, c.Lanes, lane, lanes)
+			t.Fail()
+		}
+	}
+}
+
+func TestPartitionRandom(t *testing.T) {
+	type E = elems.Int
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 500; n++ {
+		s := make([]Interval[E], rnd.Intn(30))
+		for i := range s {
+			lo := E(rnd.Intn(50))
+			s[i] = Interval[E]{lo, lo + 1 + E(rnd.Intn(10))}
+		}
+
+		depth := 0
+
+		for v := E(0); v < 60; v++ {
+			d := 0
+
+			for _, r := range s {
+				if r.Low <= v && v < r.High {
+					d++
+				}
+			}
+
+			depth = max(depth, d)
+		}
+
+		lane, lanes := Partition(s)
+		if len(lanes) != depth {
+			t.Fatalf("Partition(%v): want %v lanes, but got %v", s, depth, len(lanes))
+		}
+
+		for k, x := range lanes {
+			var y Set[E]
+
+			for i, r := range s {
+				if lane[i] != k {
+					continue
+				}
+
+				if y.Overlaps(r.Set()) {
+					t.Fatalf("Partition(%v): overlapping intervals in lane %v", s, k)
+				}
+
+				y = Add(y, r)
+			}
+
+			if !x.Equal(y) {
+				t.Fatalf("Partition(%v): lane %v: want %v, but got %v", s, k, y, x)
+			}
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package intervals
+
+import "sync/atomic"
+
+// A PersistentSet is an immutable set of separate intervals stored in a
+// persistent balanced binary search tree (a treap with path copying).
+// Add and Delete return a new PersistentSet in O(log n) expected time,
+// sharing most of the structure with the old one; the old one remains
+// unchanged. Copying a PersistentSet is cheap, which makes snapshots free.
+//
+// The zero value for a PersistentSet is an empty set.
+// A PersistentSet is safe for concurrent use by multiple goroutines.
+type PersistentSet[E Elem[E]] struct {
+	root *persistentNode[E]
+}
+
+type persistentNode[E Elem[E]] struct {
+	r           Interval[E]
+	prio        uint64
+	size        int
+	left, right *persistentNode[E]
+}
+
+var persistentSeed atomic.Uint64
+
+func persistentPrio() uint64 {
+	return mix64(persistentSeed.Add(prngGamma))
+}
+
+func newPersistentNode[E Elem[E]](r Interval[E], left, right *persistentNode[E]) *persistentNode[E] {
+	return (&persistentNode[E]{r: r, prio: persistentPrio(), left: left, right: right}).fix()
+}
+
+// with returns a copy of n with its children replaced.
+func (n *persistentNode[E]) with(left, right *persistentNode[E]) *persistentNode[E] {
+	return (&persistentNode[E]{r: n.r, prio: n.prio, left: left, right: right}).fix()
+}
+
+func (n *persistentNode[E]) fix() *persistentNode[E] {
+	n.size = 1 + n.left.len() + n.right.len()
+	return n
+}
+
+func (n *persistentNode[E]) len() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// NewPersistentSet returns a PersistentSet that contains the same elements
+// as x. NewPersistentSet runs in O(n) time.
+func NewPersistentSet[E Elem[E]](x Set[E]) PersistentSet[E] {
+	// Build a Cartesian tree from x, which is already sorted.
+	var stack []*persistentNode[E]
+
+	for _, r := range x {
+		n := &persistentNode[E]{r: r, prio: persistentPrio()}
+
+		var last *persistentNode[E]
+
+		for len(stack) != 0 && stack[len(stack)-1].prio < n.prio {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+
+		n.left = last
+
+		if len(stack) != 0 {
+			stack[len(stack)-1].right = n
+		}
+
+		stack = append(stack, n)
+	}
+
+	if len(stack) == 0 {
+		return PersistentSet[E]{}
+	}
+
+	var fixSizes func(n *persistentNode[E])
+
+	fixSizes = func(n *persistentNode[E]) {
+		if n != nil {
+			fixSizes(n.left)
+			fixSizes(n.right)
+			n.fix()
+		}
+	}
+
+	fixSizes(stack[0])
+
+	return PersistentSet[E]{stack[0]}
+}
+
+// Len returns the number of intervals in p.
+func (p PersistentSet[E]) Len() int {
+	return p.root.len()
+}
+
+// Add returns a PersistentSet that contains elements in p and in any of s.
+func (p PersistentSet[E]) Add(s ...Interval[E]) PersistentSet[E] {
+	for _, r := range s {
+		p = p.addRange(r.Low, r.High)
+	}
+
+	return p
+}
+
+func (p PersistentSet[E]) addRange(lo, hi E) PersistentSet[E] {
+	if lo.Compare(hi) >= 0 {
+		return p
+	}
+
+	// Intervals in m overlap or are adjacent to [lo, hi).
+	l, m := splitPersistent(p.root, func(r Interval[E]) bool { return r.High.Compare(lo) < 0 })
+	m, r := splitPersistent(m, func(r Interval[E]) bool { return r.Low.Compare(hi) <= 0 })
+
+	if m != nil {
+		if v := m.first().Low; v.Compare(lo) < 0 {
+			lo = v
+		}
+
+		if v := m.last().High; v.Compare(hi) > 0 {
+			hi = v
+		}
+	}
+
+	n := newPersistentNode(Range(lo, hi), nil, nil)
+
+	return PersistentSet[E]{mergePersistent(mergePersistent(l, n), r)}
+}
+
+// Delete returns a PersistentSet that contains elements in p, but not in
+// any of s.
+func (p PersistentSet[E]) Delete(s ...Interval[E]) PersistentSet[E] {
+	for _, r := range s {
+		p = p.deleteRange(r.Low, r.High)
+	}
+
+	return p
+}
+
+func (p PersistentSet[E]) deleteRange(lo, hi E) PersistentSet[E] {
+	if lo.Compare(hi) >= 0 {
+		return p
+	}
+
+	// Intervals in m overlap [lo, hi).
+	l, m := splitPersistent(p.root, func(r Interval[E]) bool { return r.High.Compare(lo) <= 0 })
+	m, r := splitPersistent(m, func(r Interval[E]) bool { return r.Low.Compare(hi) < 0 })
+
+	if m == nil {
+		return p
+	}
+
+	if v := m.first().Low; v.Compare(lo) < 0 {
+		l = mergePersistent(l, newPersistentNode(Range(v, lo), nil, nil))
+	}
+
+	if v := m.last().High; v.Compare(hi) > 0 {
+		r = mergePersistent(newPersistentNode(Range(hi, v), nil, nil), r)
+	}
+
+	return PersistentSet[E]{mergePersistent(l, r)}
+}
+
+// Contains reports whether p contains every element in range [r.Low, r.High).
+func (p PersistentSet[E]) Contains(r Interval[E]) bool {
+	n := p.search(r.Low)
+	return n != nil && n.r.Low.Compare(r.Low) <= 0 && n.r.High.Compare(r.High) >= 0 && r.Low.Compare(r.High) < 0
+}
+
+// ContainsUnit reports whether p contains a single element v.
+func (p PersistentSet[E]) ContainsUnit(v E) bool {
+	n := p.search(v)
+	return n != nil && n.r.Low.Compare(v) <= 0
+}
+
+// search returns the first node whose High is greater than v, or nil if
+// there is no such node.
+func (p PersistentSet[E]) search(v E) *persistentNode[E] {
+	var found *persistentNode[E]
+
+	for n := p.root; n != nil; {
+		if n.r.High.Compare(v) > 0 {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return found
+}
+
+// Extent returns the smallest Interval that contains every element in p.
+//
+// If p is empty, Extent returns the zero value.
+func (p PersistentSet[E]) Extent() Interval[E] {
+	if p.root == nil {
+		return Interval[E]{}
+	}
+
+	return Range(p.root.first().Low, p.root.last().High)
+}
+
+// All calls yield for each Interval in p, in ascending order, until yield
+// returns false.
+func (p PersistentSet[E]) All(yield func(Interval[E]) bool) {
+	p.root.walk(yield)
+}
+
+// Set returns the set of elements that are in p.
+func (p PersistentSet[E]) Set() Set[E] {
+	if p.root == nil {
+		return nil
+	}
+
+	x := make(Set[E], 0, p.root.size)
+
+	p.root.walk(func(r Interval[E]) bool {
+		x = append(x, r)
+		return true
+	})
+
+	return x
+}
+
+// Union returns a PersistentSet that contains elements in either p, or y,
+// or both. Union runs in O(m log n) expected time, where m is len(y).
+func (p PersistentSet[E]) Union(y Set[E]) PersistentSet[E] {
+	return p.Add(y...)
+}
+
+// Intersection returns a PersistentSet that contains elements in both p and
+// y. Intersection runs in O(m log n) expected time, where m is len(y).
+func (p PersistentSet[E]) Intersection(y Set[E]) PersistentSet[E] {
+	if p.root == nil {
+		return p
+	}
+
+	return p.Delete(p.Extent().Set().Difference(y)...)
+}
+
+// Difference returns a PersistentSet that contains elements in p, but not
+// in y. Difference runs in O(m log n) expected time, where m is len(y).
+func (p PersistentSet[E]) Difference(y Set[E]) PersistentSet[E] {
+	return p.Delete(y...)
+}
+
+// SymmetricDifference returns a PersistentSet that contains elements in one
+// of p and y, but not in both. SymmetricDifference runs in O(m log n + k)
+// expected time, where m is len(y) and k is the number of intervals in p
+// that overlap y.
+func (p PersistentSet[E]) SymmetricDifference(y Set[E]) PersistentSet[E] {
+	var common Set[E]
+
+	for _, r := range y {
+		p.root.walkRange(r.Low, r.High, func(s Interval[E]) bool {
+			if s.Low.Compare(r.Low) < 0 {
+				s.Low = r.Low
+			}
+
+			if s.High.Compare(r.High) > 0 {
+				s.High = r.High
+			}
+
+			common = append(common, s)
+
+			return true
+		})
+	}
+
+	return p.Delete(common...).Add(y.Difference(common)...)
+}
+
+func (n *persistentNode[E]) first() Interval[E] {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n.r
+}
+
+func (n *persistentNode[E]) last() Interval[E] {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n.r
+}
+
+func (n *persistentNode[E]) walk(yield func(Interval[E]) bool) bool {
+	for n != nil {
+		if !n.left.walk(yield) || !yield(n.r) {
+			return false
+		}
+
+		n = n.right
+	}
+
+	return true
+}
+
+// walkRange calls yield for each Interval in n that overlaps [lo, hi), in
+// ascending order, until yield returns false.
+func (n *persistentNode[E]) walkRange(lo, hi E, yield func(Interval[E]) bool) bool {
+	for n != nil {
+		if n.r.High.Compare(lo) <= 0 {
+			n = n.right
+			continue
+		}
+
+		if n.r.Low.Compare(hi) >= 0 {
+			n = n.left
+			continue
+		}
+
+		return n.left.walkRange(lo, hi, yield) && yield(n.r) && n.right.walkRange(lo, hi, yield)
+	}
+
+	return true
+}
+
+// splitPersistent is like splitTree, but copies nodes instead of modifying
+// them.
+func splitPersistent[E Elem[E]](n *persistentNode[E], pred func(Interval[E]) bool) (l, r *persistentNode[E]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if pred(n.r) {
+		m, r := splitPersistent(n.right, pred)
+		return n.with(n.left, m), r
+	}
+
+	l, m := splitPersistent(n.left, pred)
+
+	return l, n.with(m, n.right)
+}
+
+// mergePersistent is like mergeTree, but copies nodes instead of modifying
+// them.
+func mergePersistent[E Elem[E]](l, r *persistentNode[E]) *persistentNode[E] {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.prio > r.prio:
+		return l.with(l.left, mergePersistent(l.right, r))
+	default:
+		return r.with(mergePersistent(l, r.left), r.right)
+	}
+}
@@ -0,0 +1,81 @@
+package intervals_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestPersistentSet(t *testing.T) {
+	type E = elems.Int
+
+	var p0 PersistentSet[E]
+
+	p1 := p0.Add(Range[E](1, 5), Range[E](11, 15))
+	p2 := p1.Add(Range[E](5, 11))
+	p3 := p1.Delete(Range[E](2, 3), Range[E](15, 13))
+	p4 := p2.Delete(Range[E](0, 20))
+	p5 := NewPersistentSet(Set[E]{{1, 3}, {5, 11}, {13, 15}})
+
+	testCases := []struct {
+		Actual, Expected Set[E]
+	}{
+		{p0.Set(), nil},
+		{p1.Set(), Set[E]{{1, 5}, {11, 15}}},
+		{p2.Set(), Set[E]{{1, 15}}},
+		{p3.Set(), Set[E]{{1, 2}, {3, 5}, {11, 15}}},
+		{p4.Set(), nil},
+		{p5.Set(), Set[E]{{1, 3}, {5, 11}, {13, 15}}},
+		{p5.Union(Set[E]{{3, 4}, {11, 13}}).Set(), Set[E]{{1, 4}, {5, 15}}},
+		{p5.Intersection(Set[E]{{2, 6}, {10, 14}}).Set(), Set[E]{{2, 3}, {5, 6}, {10, 11}, {13, 14}}},
+		{p5.Difference(Set[E]{{2, 6}, {10, 14}}).Set(), Set[E]{{1, 2}, {6, 10}, {14, 15}}},
+		{p5.SymmetricDifference(Set[E]{{2, 6}, {10, 14}}).Set(), Set[E]{{1, 2}, {3, 5}, {6, 10}, {11, 13}, {14, 15}}},
+		{p0.Intersection(Set[E]{{2, 6}}).Set(), nil},
+		{p5.Set(), Set[E]{{1, 3}, {5, 11}, {13, 15}}},
+	}
+
+	for i, c := range testCases {
+		if !c.Actual.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+		}
+	}
+}
+
+func TestPersistentSetQueries(t *testing.T) {
+	type E = elems.Int
+
+	s := NewPersistentSet(Set[E]{{1, 3}, {5, 7}})
+
+	var first Set[E]
+
+	s.All(func(r Interval[E]) bool {
+		first = append(first, r)
+		return false
+	})
+
+	assertions := []bool{
+		s.Len() == 2,
+		s.ContainsUnit(0) == false,
+		s.ContainsUnit(1) == true,
+		s.ContainsUnit(3) == false,
+		s.ContainsUnit(6) == true,
+		s.ContainsUnit(7) == false,
+		s.Contains(Range[E](1, 3)) == true,
+		s.Contains(Range[E](3, 5)) == false,
+		s.Contains(Range[E](1, 7)) == false,
+		s.Contains(Range[E](2, 2)) == false,
+		s.Extent() == Range[E](1, 7),
+		PersistentSet[E]{}.Extent() == Interval[E]{},
+		PersistentSet[E]{}.Len() == 0,
+		first.Equal(Set[E]{{1, 3}}),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
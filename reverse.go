@@ -0,0 +1,51 @@
+package intervals
+
+import "github.com/b97tsk/intervals/elems"
+
+// ReverseSet returns a Set of elems.BiEnumReverse that contains the same
+// elements as x. Interval [lo, hi) of x becomes [hi.Prev(), lo.Prev()) in
+// the result.
+//
+// Since Intervals are half-open, the minimum value of E, which would be the
+// maximum value of elems.BiEnumReverse[E], cannot be in the result.
+// ReverseSet drops it.
+func ReverseSet[E BiEnum[E]](x Set[E]) Set[elems.BiEnumReverse[E]] {
+	z := make(Set[elems.BiEnumReverse[E]], 0, len(x))
+
+	for i := len(x) - 1; i >= 0; i-- {
+		lo, hi := elems.BiEnumReverse[E]{V: x[i].High.Prev()}, elems.BiEnumReverse[E]{V: x[i].Low.Prev()}
+
+		if hi.V.Compare(x[i].Low) >= 0 { // x[i].Low is the minimum value of E.
+			hi.V = x[i].Low
+		}
+
+		if lo.Compare(hi) < 0 {
+			z = append(z, Range(lo, hi))
+		}
+	}
+
+	return z
+}
+
+// UnreverseSet returns a Set of E that contains the same elements as x.
+// It is the inverse of ReverseSet.
+//
+// Since Intervals are half-open, the maximum value of E cannot be in the
+// result. UnreverseSet drops it.
+func UnreverseSet[E BiEnum[E]](x Set[elems.BiEnumReverse[E]]) Set[E] {
+	z := make(Set[E], 0, len(x))
+
+	for i := len(x) - 1; i >= 0; i-- {
+		lo, hi := x[i].High.V.Next(), x[i].Low.V.Next()
+
+		if hi.Compare(x[i].Low.V) <= 0 { // x[i].Low.V is the maximum value of E.
+			hi = x[i].Low.V
+		}
+
+		if lo.Compare(hi) < 0 {
+			z = append(z, Range(lo, hi))
+		}
+	}
+
+	return z
+}
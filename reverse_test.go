@@ -0,0 +1,42 @@
+package intervals_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestReverseSet(t *testing.T) {
+	type E = elems.Int
+	type R = elems.BiEnumReverse[E]
+
+	type U = elems.Uint8
+	type RU = elems.BiEnumReverse[U]
+
+	r := func(lo, hi E) Interval[R] { return Range(R{V: lo}, R{V: hi}) }
+	ru := func(lo, hi U) Interval[RU] { return Range(RU{V: lo}, RU{V: hi}) }
+
+	x := Collect(Range[E](1, 3), Range[E](5, 8))
+	u := Collect(Range[U](0, 3), Range[U](254, 255))
+
+	assertions := []bool{
+		ReverseSet(x).Equal(Collect(r(7, 4), r(2, 0))),
+		UnreverseSet(ReverseSet(x)).Equal(x),
+		len(ReverseSet[E](nil)) == 0,
+		len(UnreverseSet[E](nil)) == 0,
+		// The minimum value of U is dropped.
+		ReverseSet(u).Equal(Collect(ru(254, 253), ru(2, 0))),
+		UnreverseSet(ReverseSet(u)).Equal(Collect(Range[U](1, 3), Range[U](254, 255))),
+		ReverseSet(Collect(Range[U](0, 1))).Equal(nil),
+		// The maximum value of U is dropped.
+		UnreverseSet(Collect(ru(255, 253))).Equal(Collect(Range[U](254, 255))),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package roaring provides a compressed set of 32-bit unsigned integers in
+// the style of Roaring bitmaps, which can be converted from and into
+// [intervals.Set].
+//
+// A Bitmap splits elements into chunks by their high 16 bits, and stores
+// the low 16 bits of elements in each chunk in whichever container takes
+// the least memory: a sorted array, a bitmap, or a list of runs.
+// For sets made of many tiny scattered intervals, a Bitmap can take much
+// less memory than an intervals.Set.
+//
+// Bitmap and Bitmap64 can be read and written in the Roaring portable
+// serialization format, which is understood by Roaring implementations in
+// other languages.
+package roaring
+
+import (
+	"sort"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// Elem is the type set containing all supported element types.
+type Elem[E any] interface {
+	~uint8 | ~uint16 | ~uint32
+	intervals.Elem[E]
+}
+
+// A Bitmap is a set of 32-bit unsigned integers.
+// Set operations never modify their operands; only UnmarshalBinary and
+// ReadFrom overwrite a Bitmap, which must not be used concurrently with
+// other methods.
+// The zero value for a Bitmap is an empty set.
+type Bitmap struct {
+	keys       []uint16 // High 16 bits, in ascending order.
+	containers []container
+}
+
+// FromSet returns a Bitmap that contains the same elements as x.
+func FromSet[E Elem[E]](x intervals.Set[E]) *Bitmap {
+	var bd builder
+
+	for _, r := range x {
+		bd.add(uint64(r.Low), uint64(r.High))
+	}
+
+	return bd.finish()
+}
+
+// A builder builds a Bitmap from ranges added in ascending order.
+type builder struct {
+	b   Bitmap
+	s   spans
+	key uint64
+}
+
+// add adds range [lo, hi) into bd, where hi <= 1<<32.
+func (bd *builder) add(lo, hi uint64) {
+	for lo < hi {
+		if k := lo >> 16; k != bd.key || len(bd.s) == 0 {
+			bd.flush()
+			bd.key = k
+		}
+
+		base := bd.key << 16
+		end := min(hi, base+chunkSize)
+		bd.s = append(bd.s, intervals.Range(elems.Uint32(lo-base), elems.Uint32(end-base)))
+		lo = end
+	}
+}
+
+func (bd *builder) flush() {
+	if c := newContainer(bd.s); c != nil {
+		bd.b.keys = append(bd.b.keys, uint16(bd.key))
+		bd.b.containers = append(bd.b.containers, c)
+	}
+
+	bd.s = bd.s[:0]
+}
+
+func (bd *builder) finish() *Bitmap {
+	bd.flush()
+	return &Bitmap{bd.b.keys, bd.b.containers}
+}
+
+// ToSet returns the set of elements that are in b.
+//
+// Since Intervals are half-open, the maximum value of E cannot be in the
+// result. ToSet drops it, along with any element that E cannot represent.
+func ToSet[E Elem[E]](b *Bitmap) intervals.Set[E] {
+	x, _ := appendToSet[E](nil, b, 0, uint64(^E(0)))
+	return x
+}
+
+// appendToSet appends elements in b, plus base, that are less than limit
+// into x, returning the extended Set, and reports whether all elements
+// in b are less than limit.
+func appendToSet[E Elem64[E]](x intervals.Set[E], b *Bitmap, base, limit uint64) (intervals.Set[E], bool) {
+	for i, c := range b.containers {
+		base := base + uint64(b.keys[i])<<16
+
+		for _, r := range c.spans() {
+			lo, hi := base+uint64(r.Low), base+uint64(r.High)
+
+			if hi < base || hi > limit { // hi overflows or exceeds limit.
+				hi = limit
+			}
+
+			if lo >= hi {
+				return x, false
+			}
+
+			if n := len(x); n != 0 && uint64(x[n-1].High) == lo {
+				x[n-1].High = E(hi)
+				continue
+			}
+
+			x = append(x, intervals.Range(E(lo), E(hi)))
+		}
+	}
+
+	return x, true
+}
+
+// Contains reports whether b contains v.
+func (b *Bitmap) Contains(v uint32) bool {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= uint16(v>>16) })
+	return i < len(b.keys) && b.keys[i] == uint16(v>>16) && b.containers[i].contains(uint16(v))
+}
+
+// Cardinality returns the number of elements in b.
+func (b *Bitmap) Cardinality() uint64 {
+	var n uint64
+
+	for _, c := range b.containers {
+		n += uint64(c.cardinality())
+	}
+
+	return n
+}
+
+// Equal reports whether b contains the same elements as b2.
+func (b *Bitmap) Equal(b2 *Bitmap) bool {
+	if len(b.keys) != len(b2.keys) {
+		return false
+	}
+
+	for i, k := range b.keys {
+		if k != b2.keys[i] || !b.containers[i].spans().Equal(b2.containers[i].spans()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union returns the set of elements that are in either b, or b2, or both.
+func (b *Bitmap) Union(b2 *Bitmap) *Bitmap {
+	z := new(Bitmap)
+	i, j := 0, 0
+
+	for i < len(b.keys) && j < len(b2.keys) {
+		switch k1, k2 := b.keys[i], b2.keys[j]; {
+		case k1 < k2:
+			z.keys = append(z.keys, k1)
+			z.containers = append(z.containers, b.containers[i])
+			i++
+		case k1 > k2:
+			z.keys = append(z.keys, k2)
+			z.containers = append(z.containers, b2.containers[j])
+			j++
+		default:
+			z.keys = append(z.keys, k1)
+			z.containers = append(z.containers, unionContainer(b.containers[i], b2.containers[j]))
+			i++
+			j++
+		}
+	}
+
+	z.keys = append(append(z.keys, b.keys[i:]...), b2.keys[j:]...)
+	z.containers = append(append(z.containers, b.containers[i:]...), b2.containers[j:]...)
+
+	return z
+}
+
+// Intersection returns the set of elements that are in both b and b2.
+func (b *Bitmap) Intersection(b2 *Bitmap) *Bitmap {
+	z := new(Bitmap)
+	i, j := 0, 0
+
+	for i < len(b.keys) && j < len(b2.keys) {
+		switch k1, k2 := b.keys[i], b2.keys[j]; {
+		case k1 < k2:
+			i++
+		case k1 > k2:
+			j++
+		default:
+			if c := intersectionContainer(b.containers[i], b2.containers[j]); c != nil {
+				z.keys = append(z.keys, k1)
+				z.containers = append(z.containers, c)
+			}
+
+			i++
+			j++
+		}
+	}
+
+	return z
+}
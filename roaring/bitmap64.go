@@ -0,0 +1,184 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/b97tsk/intervals"
+)
+
+// Elem64 is the type set containing all supported element types of
+// Bitmap64.
+type Elem64[E any] interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+	intervals.Elem[E]
+}
+
+// A Bitmap64 is a set of 64-bit unsigned integers, made of Bitmaps keyed by
+// the high 32 bits of elements.
+// Only UnmarshalBinary and ReadFrom modify a Bitmap64, which must not be used
+// concurrently with other methods.
+// The zero value for a Bitmap64 is an empty set.
+type Bitmap64 struct {
+	keys    []uint32 // High 32 bits, in ascending order.
+	bitmaps []*Bitmap
+}
+
+// FromSet64 returns a Bitmap64 that contains the same elements as x.
+func FromSet64[E Elem64[E]](x intervals.Set[E]) *Bitmap64 {
+	b := new(Bitmap64)
+
+	var bd builder
+
+	key := uint64(0)
+
+	flush := func() {
+		if bm := bd.finish(); len(bm.keys) != 0 {
+			b.keys = append(b.keys, uint32(key))
+			b.bitmaps = append(b.bitmaps, bm)
+		}
+
+		bd = builder{}
+	}
+
+	for _, r := range x {
+		lo, hi := uint64(r.Low), uint64(r.High)
+
+		for lo < hi {
+			if k := lo >> 32; k != key {
+				flush()
+				key = k
+			}
+
+			base := key << 32
+			end := hi
+
+			if key < 1<<32-1 {
+				end = min(hi, base+1<<32)
+			}
+
+			bd.add(lo-base, end-base)
+			lo = end
+		}
+	}
+
+	flush()
+
+	return b
+}
+
+// ToSet64 returns the set of elements that are in b.
+//
+// Since Intervals are half-open, the maximum value of E cannot be in the
+// result. ToSet64 drops it, along with any element that E cannot represent.
+func ToSet64[E Elem64[E]](b *Bitmap64) intervals.Set[E] {
+	var x intervals.Set[E]
+
+	for i, bm := range b.bitmaps {
+		var ok bool
+
+		if x, ok = appendToSet(x, bm, uint64(b.keys[i])<<32, uint64(^E(0))); !ok {
+			break
+		}
+	}
+
+	return x
+}
+
+// Contains reports whether b contains v.
+func (b *Bitmap64) Contains(v uint64) bool {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= uint32(v>>32) })
+	return i < len(b.keys) && b.keys[i] == uint32(v>>32) && b.bitmaps[i].Contains(uint32(v))
+}
+
+// Cardinality returns the number of elements in b.
+func (b *Bitmap64) Cardinality() uint64 {
+	var n uint64
+
+	for _, bm := range b.bitmaps {
+		n += bm.Cardinality()
+	}
+
+	return n
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The output is in the portable serialization format of 64-bit Roaring
+// bitmaps.
+func (b *Bitmap64) MarshalBinary() ([]byte, error) {
+	return b.appendBinary(nil), nil
+}
+
+// WriteTo writes b to w in the portable serialization format of 64-bit
+// Roaring bitmaps. It implements the io.WriterTo interface.
+func (b *Bitmap64) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.appendBinary(nil))
+	return int64(n), err
+}
+
+func (b *Bitmap64) appendBinary(buf []byte) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(b.keys)))
+
+	for i, bm := range b.bitmaps {
+		buf = binary.LittleEndian.AppendUint32(buf, b.keys[i])
+		buf = bm.appendBinary(buf)
+	}
+
+	return buf
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The input is expected to be in the portable serialization format of
+// 64-bit Roaring bitmaps.
+func (b *Bitmap64) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	if _, err := b.ReadFrom(r); err != nil {
+		return err
+	}
+
+	if r.Len() != 0 {
+		return errInvalidFormat
+	}
+
+	return nil
+}
+
+// ReadFrom reads b from r in the portable serialization format of 64-bit
+// Roaring bitmaps, replacing the contents of b. ReadFrom reads no more bytes
+// than needed. It implements the io.ReaderFrom interface.
+func (b *Bitmap64) ReadFrom(r io.Reader) (int64, error) {
+	d := decoder{r: r}
+	b2 := new(Bitmap64)
+
+	var prevKey uint32
+
+	for i, n := uint64(0), d.uint64(); i < n && d.err == nil; i++ {
+		key := d.uint32()
+
+		if i > 0 && key <= prevKey {
+			d.fail()
+		}
+
+		prevKey = key
+
+		if bm := d.readBitmap(); bm != nil && len(bm.keys) != 0 {
+			b2.keys = append(b2.keys, key)
+			b2.bitmaps = append(b2.bitmaps, bm)
+		}
+	}
+
+	if d.err != nil {
+		if d.err == io.EOF && d.n != 0 {
+			d.err = io.ErrUnexpectedEOF
+		}
+
+		return d.n, d.err
+	}
+
+	*b = *b2
+
+	return d.n, nil
+}
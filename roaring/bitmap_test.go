@@ -0,0 +1,163 @@
+package roaring_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/roaring"
+)
+
+type E = elems.Uint32
+
+func TestBitmap(t *testing.T) {
+	x := intervals.Collect(
+		intervals.Range[E](1, 3),
+		intervals.Range[E](65530, 65542),
+		intervals.Range[E](1<<20, 1<<21),
+		intervals.Range[E](math32-5, math32),
+	)
+
+	b := roaring.FromSet(x)
+	small := intervals.Collect(intervals.Range[elems.Uint8](1, 3), intervals.Range[elems.Uint8](250, 255))
+
+	assertions := []bool{
+		roaring.ToSet[E](b).Equal(x),
+		b.Cardinality() == 2+12+(1<<20)+5,
+		b.Contains(1),
+		b.Contains(2),
+		!b.Contains(3),
+		b.Contains(65535),
+		b.Contains(65536),
+		!b.Contains(65542),
+		b.Contains(1<<20 + 12345),
+		!b.Contains(1 << 21),
+		!b.Contains(math32),
+		roaring.ToSet[E](new(roaring.Bitmap)) == nil,
+		new(roaring.Bitmap).Cardinality() == 0,
+		roaring.ToSet[elems.Uint16](b).Equal(intervals.Collect(intervals.Range[elems.Uint16](1, 3), intervals.Range[elems.Uint16](65530, 65535))),
+		roaring.ToSet[elems.Uint8](roaring.FromSet(small)).Equal(small),
+		b.Equal(roaring.FromSet(x)),
+		!b.Equal(new(roaring.Bitmap)),
+		!b.Equal(roaring.FromSet(x[1:])),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+const math32 = 1<<32 - 1
+
+func TestBitmapOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		x, y := randomSet(rng), randomSet(rng)
+		bx, by := roaring.FromSet(x), roaring.FromSet(y)
+
+		if got, want := roaring.ToSet[E](bx.Union(by)), x.Union(y); !got.Equal(want) {
+			t.Fatalf("Union: want %v, but got %v", want, got)
+		}
+
+		if got, want := roaring.ToSet[E](bx.Intersection(by)), x.Intersection(y); !got.Equal(want) {
+			t.Fatalf("Intersection: want %v, but got %v", want, got)
+		}
+	}
+}
+
+// randomSet returns a random set that mixes dense runs with scattered
+// elements, in order to exercise all kinds of containers.
+func randomSet(rng *rand.Rand) intervals.Set[E] {
+	var s []intervals.Interval[E]
+
+	for k := 0; k < 4; k++ {
+		base := E(rng.Intn(4)) << 16
+
+		switch rng.Intn(3) {
+		case 0: // Sparse.
+			for i := 0; i < 1+rng.Intn(100); i++ {
+				s = append(s, intervals.Unit(base+E(rng.Intn(1<<16))))
+			}
+		case 1: // Dense, scattered.
+			for i := 0; i < 5000+rng.Intn(10000); i++ {
+				lo := base + E(rng.Intn(1<<16-3))
+				s = append(s, intervals.Range(lo, lo+E(1+rng.Intn(3))))
+			}
+		default: // Runs.
+			for i := 0; i < 1+rng.Intn(20); i++ {
+				lo := base + E(rng.Intn(1<<16-1000))
+				s = append(s, intervals.Range(lo, lo+E(1+rng.Intn(1000))))
+			}
+		}
+	}
+
+	return intervals.Collect(s...)
+}
+
+func BenchmarkUnion(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	x, y := scatteredSet(rng), scatteredSet(rng)
+
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = x.Union(y)
+		}
+	})
+
+	b.Run("Bitmap", func(b *testing.B) {
+		bx, by := roaring.FromSet(x), roaring.FromSet(y)
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = bx.Union(by)
+		}
+	})
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	x, y := scatteredSet(rng), scatteredSet(rng)
+
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = x.Intersection(y)
+		}
+	})
+
+	b.Run("Bitmap", func(b *testing.B) {
+		bx, by := roaring.FromSet(x), roaring.FromSet(y)
+
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = bx.Intersection(by)
+		}
+	})
+}
+
+// scatteredSet returns a set of many tiny scattered intervals, where a
+// Bitmap is expected to shine.
+func scatteredSet(rng *rand.Rand) intervals.Set[E] {
+	v := make([]E, 1<<18)
+
+	for i := range v {
+		v[i] = E(rng.Intn(1 << 22))
+	}
+
+	slices.Sort(v)
+
+	s := make([]intervals.Interval[E], len(v))
+
+	for i := range v {
+		s[i] = intervals.Unit(v[i])
+	}
+
+	return intervals.Collect(s...)
+}
@@ -0,0 +1,350 @@
+package roaring
+
+import (
+	"math/bits"
+	"slices"
+	"sort"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// A span is a half-open range of low 16 bits within a chunk. High can be
+// up to 1<<16.
+type span = intervals.Interval[elems.Uint32]
+
+// spans is a set of spans within a chunk.
+type spans = intervals.Set[elems.Uint32]
+
+const (
+	chunkSize      = 1 << 16
+	bitmapWords    = chunkSize / 64
+	bitmapBytes    = chunkSize / 8
+	arrayMaxLength = 4096
+)
+
+// A container holds the low 16 bits of elements in a chunk.
+// A container is never empty and is never modified once created.
+type container interface {
+	contains(v uint16) bool
+	cardinality() int
+	numRuns() int
+	spans() spans
+}
+
+// An arrayContainer is a sorted array of elements.
+type arrayContainer []uint16
+
+// A bitmapContainer is a bitmap of bitmapWords words.
+type bitmapContainer []uint64
+
+// A runContainer is a sorted list of runs.
+type runContainer []run
+
+// A run is a closed range [start, last] of elements.
+type run struct {
+	start, last uint16
+}
+
+func (c arrayContainer) contains(v uint16) bool {
+	_, found := slices.BinarySearch(c, v)
+	return found
+}
+
+func (c arrayContainer) cardinality() int { return len(c) }
+
+func (c arrayContainer) numRuns() int {
+	n := 1
+
+	for i := 1; i < len(c); i++ {
+		if c[i] != c[i-1]+1 {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (c arrayContainer) spans() spans {
+	var s spans
+
+	for _, v := range c {
+		if n := len(s); n != 0 && s[n-1].High == elems.Uint32(v) {
+			s[n-1].High++
+			continue
+		}
+
+		s = append(s, intervals.Unit(elems.Uint32(v)))
+	}
+
+	return s
+}
+
+func (c bitmapContainer) contains(v uint16) bool {
+	return c[v/64]&(1<<(v%64)) != 0
+}
+
+func (c bitmapContainer) cardinality() int {
+	n := 0
+
+	for _, w := range c {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+func (c bitmapContainer) numRuns() int {
+	n := 0
+
+	var carry uint64
+
+	for _, w := range c {
+		// Count bits that are set but whose previous bits are not.
+		n += bits.OnesCount64(w &^ (w<<1 | carry))
+		carry = w >> 63
+	}
+
+	return n
+}
+
+func (c bitmapContainer) spans() spans {
+	var s spans
+
+	for i, w := range c {
+		base := uint32(i * 64)
+
+		for w != 0 {
+			lo := uint32(bits.TrailingZeros64(w))
+			hi := uint32(bits.TrailingZeros64(^(w >> lo))) + lo
+			w &^= (1<<(hi-lo) - 1) << lo
+
+			if hi == 64 {
+				w = 0
+			}
+
+			r := intervals.Range(elems.Uint32(base+lo), elems.Uint32(base+hi))
+
+			if n := len(s); n != 0 && s[n-1].High == r.Low {
+				s[n-1].High = r.High
+				continue
+			}
+
+			s = append(s, r)
+		}
+	}
+
+	return s
+}
+
+func (c runContainer) contains(v uint16) bool {
+	i := sort.Search(len(c), func(i int) bool { return c[i].last >= v })
+	return i < len(c) && c[i].start <= v
+}
+
+func (c runContainer) cardinality() int {
+	n := 0
+
+	for _, r := range c {
+		n += int(r.last-r.start) + 1
+	}
+
+	return n
+}
+
+func (c runContainer) numRuns() int { return len(c) }
+
+func (c runContainer) spans() spans {
+	s := make(spans, len(c))
+
+	for i, r := range c {
+		s[i] = intervals.Range(elems.Uint32(r.start), elems.Uint32(r.last)+1)
+	}
+
+	return s
+}
+
+// newContainer returns the smallest container that holds elements in s,
+// or nil if s is empty.
+func newContainer(s spans) container {
+	if len(s) == 0 {
+		return nil
+	}
+
+	card := 0
+
+	for _, r := range s {
+		card += int(r.High - r.Low)
+	}
+
+	switch bestKind(card, len(s)) {
+	case kindRun:
+		c := make(runContainer, len(s))
+
+		for i, r := range s {
+			c[i] = run{uint16(r.Low), uint16(r.High - 1)}
+		}
+
+		return c
+	case kindArray:
+		c := make(arrayContainer, 0, card)
+
+		for _, r := range s {
+			for v := r.Low; v < r.High; v++ {
+				c = append(c, uint16(v))
+			}
+		}
+
+		return c
+	default:
+		c := make(bitmapContainer, bitmapWords)
+
+		for _, r := range s {
+			for v := uint32(r.Low); v < uint32(r.High); v++ {
+				c[v/64] |= 1 << (v % 64)
+			}
+		}
+
+		return c
+	}
+}
+
+type containerKind int
+
+const (
+	kindArray containerKind = iota
+	kindBitmap
+	kindRun
+)
+
+// bestKind returns the kind of container that takes the least memory to
+// hold card elements in nruns runs.
+func bestKind(card, nruns int) containerKind {
+	runSize := 2 + 4*nruns
+
+	switch {
+	case runSize < min(2*card, bitmapBytes):
+		return kindRun
+	case card <= arrayMaxLength:
+		return kindArray
+	default:
+		return kindBitmap
+	}
+}
+
+func kindOf(c container) containerKind {
+	switch c.(type) {
+	case arrayContainer:
+		return kindArray
+	case bitmapContainer:
+		return kindBitmap
+	default:
+		return kindRun
+	}
+}
+
+// optimize returns a container that holds the same elements as c and takes
+// the least memory, or nil if c is empty.
+func optimize(c container) container {
+	card := c.cardinality()
+	if card == 0 {
+		return nil
+	}
+
+	if kindOf(c) == bestKind(card, c.numRuns()) {
+		return c
+	}
+
+	return newContainer(c.spans())
+}
+
+func unionContainer(c1, c2 container) container {
+	switch c1 := c1.(type) {
+	case bitmapContainer:
+		switch c2 := c2.(type) {
+		case bitmapContainer:
+			c := make(bitmapContainer, bitmapWords)
+
+			for i := range c {
+				c[i] = c1[i] | c2[i]
+			}
+
+			return optimize(c)
+		case arrayContainer:
+			c := slices.Clone(c1)
+
+			for _, v := range c2 {
+				c[v/64] |= 1 << (v % 64)
+			}
+
+			return optimize(c)
+		}
+	case arrayContainer:
+		switch c2.(type) {
+		case bitmapContainer:
+			return unionContainer(c2, c1)
+		case arrayContainer:
+			c := make(arrayContainer, 0, len(c1)+len(c2.(arrayContainer)))
+			x, y := c1, c2.(arrayContainer)
+
+			for len(x) != 0 && len(y) != 0 {
+				switch {
+				case x[0] < y[0]:
+					c = append(c, x[0])
+					x = x[1:]
+				case x[0] > y[0]:
+					c = append(c, y[0])
+					y = y[1:]
+				default:
+					c = append(c, x[0])
+					x, y = x[1:], y[1:]
+				}
+			}
+
+			c = append(append(c, x...), y...)
+
+			if len(c) > arrayMaxLength {
+				return newContainer(c.spans())
+			}
+
+			return optimize(c)
+		}
+	}
+
+	return newContainer(c1.spans().Union(c2.spans()))
+}
+
+func intersectionContainer(c1, c2 container) container {
+	if _, ok := c2.(arrayContainer); ok {
+		c1, c2 = c2, c1
+	}
+
+	switch x := c1.(type) {
+	case arrayContainer:
+		var c arrayContainer
+
+		for _, v := range x {
+			if c2.contains(v) {
+				c = append(c, v)
+			}
+		}
+
+		if len(c) == 0 {
+			return nil
+		}
+
+		return optimize(c)
+	case bitmapContainer:
+		if y, ok := c2.(bitmapContainer); ok {
+			c := make(bitmapContainer, bitmapWords)
+
+			for i := range c {
+				c[i] = x[i] & y[i]
+			}
+
+			return optimize(c)
+		}
+	}
+
+	return newContainer(c1.spans().Intersection(c2.spans()))
+}
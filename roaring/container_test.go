@@ -0,0 +1,121 @@
+package roaring
+
+import (
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestContainer(t *testing.T) {
+	every := func(step uint32) spans {
+		var s spans
+
+		for v := uint32(0); v < chunkSize; v += step {
+			s = append(s, intervals.Unit(elems.Uint32(v)))
+		}
+
+		return s
+	}
+
+	testCases := []struct {
+		Spans spans
+		Kind  containerKind
+	}{
+		{spans{sp(0, 1)}, kindArray},
+		{spans{sp(0, 100)}, kindRun},
+		{spans{sp(0, 1), sp(2, 3), sp(4, 5)}, kindArray},
+		{spans{sp(0, chunkSize)}, kindRun},
+		{every(2), kindBitmap},
+		{every(16), kindArray},
+		{every(15), kindBitmap},
+		{spans{sp(0, 63), sp(64, 129), sp(200, 256), sp(chunkSize-1, chunkSize)}, kindRun},
+	}
+
+	for i, c := range testCases {
+		x := newContainer(c.Spans)
+
+		if kind := kindOf(x); kind != c.Kind {
+			t.Errorf("Case %v: want kind %v, but got %v", i, c.Kind, kind)
+		}
+
+		if s := x.spans(); !s.Equal(c.Spans) {
+			t.Errorf("Case %v: want %v, but got %v", i, c.Spans, s)
+		}
+
+		card := 0
+
+		for _, r := range c.Spans {
+			card += int(r.High - r.Low)
+		}
+
+		if x.cardinality() != card || x.numRuns() != len(c.Spans) {
+			t.Errorf("Case %v: want (%v, %v), but got (%v, %v)", i, card, len(c.Spans), x.cardinality(), x.numRuns())
+		}
+
+		for _, k := range []containerKind{kindArray, kindBitmap, kindRun} {
+			y := convert(x, k)
+
+			for v := 0; v < chunkSize; v++ {
+				if y.contains(uint16(v)) != c.Spans.ContainsUnit(elems.Uint32(v)) {
+					t.Fatalf("Case %v: kind %v: contains(%v) didn't work", i, k, v)
+				}
+			}
+
+			if s := y.spans(); !s.Equal(c.Spans) {
+				t.Errorf("Case %v: kind %v: want %v, but got %v", i, k, c.Spans, s)
+			}
+
+			if kind := kindOf(optimize(y)); kind != c.Kind {
+				t.Errorf("Case %v: kind %v: optimize: want kind %v, but got %v", i, k, c.Kind, kind)
+			}
+		}
+	}
+
+	if newContainer(nil) != nil {
+		t.Error("newContainer(nil) didn't return nil")
+	}
+}
+
+// convert returns a container of kind k that holds the same elements as c,
+// regardless of whether k is the best kind.
+func convert(c container, k containerKind) container {
+	var x container
+
+	switch k {
+	case kindArray:
+		var a arrayContainer
+
+		for _, r := range c.spans() {
+			for v := r.Low; v < r.High; v++ {
+				a = append(a, uint16(v))
+			}
+		}
+
+		x = a
+	case kindBitmap:
+		b := make(bitmapContainer, bitmapWords)
+
+		for _, r := range c.spans() {
+			for v := r.Low; v < r.High; v++ {
+				b[v/64] |= 1 << (v % 64)
+			}
+		}
+
+		x = b
+	default:
+		var a runContainer
+
+		for _, r := range c.spans() {
+			a = append(a, run{uint16(r.Low), uint16(r.High - 1)})
+		}
+
+		x = a
+	}
+
+	return x
+}
+
+func sp(lo, hi uint32) span {
+	return intervals.Range(elems.Uint32(lo), elems.Uint32(hi))
+}
@@ -0,0 +1,330 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// Constants of the Roaring portable serialization format.
+// See https://github.com/RoaringBitmap/RoaringFormatSpec.
+const (
+	serialCookieNoRunContainer = 12346
+	serialCookie               = 12347
+	noOffsetThreshold          = 4
+)
+
+var errInvalidFormat = errors.New("roaring: invalid format")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The output is in the Roaring portable serialization format.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	return b.appendBinary(nil), nil
+}
+
+// WriteTo writes b to w in the Roaring portable serialization format.
+// It implements the io.WriterTo interface.
+func (b *Bitmap) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.appendBinary(nil))
+	return int64(n), err
+}
+
+func (b *Bitmap) appendBinary(buf []byte) []byte {
+	le := binary.LittleEndian
+	size := len(b.containers)
+	start := len(buf)
+
+	hasRun := false
+
+	for _, c := range b.containers {
+		if _, ok := c.(runContainer); ok {
+			hasRun = true
+			break
+		}
+	}
+
+	if hasRun {
+		buf = le.AppendUint32(buf, serialCookie|uint32(size-1)<<16)
+		runs := make([]byte, (size+7)/8)
+
+		for i, c := range b.containers {
+			if _, ok := c.(runContainer); ok {
+				runs[i/8] |= 1 << (i % 8)
+			}
+		}
+
+		buf = append(buf, runs...)
+	} else {
+		buf = le.AppendUint32(buf, serialCookieNoRunContainer)
+		buf = le.AppendUint32(buf, uint32(size))
+	}
+
+	for i, c := range b.containers {
+		buf = le.AppendUint16(buf, b.keys[i])
+		buf = le.AppendUint16(buf, uint16(c.cardinality()-1))
+	}
+
+	if !hasRun || size >= noOffsetThreshold {
+		offset := len(buf) - start + 4*size
+
+		for _, c := range b.containers {
+			buf = le.AppendUint32(buf, uint32(offset))
+			offset += containerSize(c)
+		}
+	}
+
+	for _, c := range b.containers {
+		switch c := c.(type) {
+		case arrayContainer:
+			for _, v := range c {
+				buf = le.AppendUint16(buf, v)
+			}
+		case bitmapContainer:
+			for _, w := range c {
+				buf = le.AppendUint64(buf, w)
+			}
+		case runContainer:
+			buf = le.AppendUint16(buf, uint16(len(c)))
+
+			for _, r := range c {
+				buf = le.AppendUint16(buf, r.start)
+				buf = le.AppendUint16(buf, r.last-r.start)
+			}
+		}
+	}
+
+	return buf
+}
+
+// containerSize returns the number of bytes c takes when serialized.
+func containerSize(c container) int {
+	switch c := c.(type) {
+	case arrayContainer:
+		return 2 * len(c)
+	case bitmapContainer:
+		return bitmapBytes
+	default:
+		return 2 + 4*c.numRuns()
+	}
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The input is expected to be in the Roaring portable serialization format.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	if _, err := b.ReadFrom(r); err != nil {
+		return err
+	}
+
+	if r.Len() != 0 {
+		return errInvalidFormat
+	}
+
+	return nil
+}
+
+// ReadFrom reads b from r in the Roaring portable serialization format,
+// replacing the contents of b. ReadFrom reads no more bytes than needed.
+// It implements the io.ReaderFrom interface.
+func (b *Bitmap) ReadFrom(r io.Reader) (int64, error) {
+	d := decoder{r: r}
+	b2 := d.readBitmap()
+
+	if d.err != nil {
+		if d.err == io.EOF && d.n != 0 {
+			d.err = io.ErrUnexpectedEOF
+		}
+
+		return d.n, d.err
+	}
+
+	*b = *b2
+
+	return d.n, nil
+}
+
+type decoder struct {
+	r   io.Reader
+	n   int64
+	err error
+	buf [8]byte
+}
+
+func (d *decoder) read(p []byte) {
+	if d.err == nil {
+		var n int
+		n, d.err = io.ReadFull(d.r, p)
+		d.n += int64(n)
+	}
+}
+
+func (d *decoder) uint16() uint16 {
+	d.read(d.buf[:2])
+	return binary.LittleEndian.Uint16(d.buf[:])
+}
+
+func (d *decoder) uint32() uint32 {
+	d.read(d.buf[:4])
+	return binary.LittleEndian.Uint32(d.buf[:])
+}
+
+func (d *decoder) uint64() uint64 {
+	d.read(d.buf[:8])
+	return binary.LittleEndian.Uint64(d.buf[:])
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = errInvalidFormat
+	}
+}
+
+func (d *decoder) readBitmap() *Bitmap {
+	var size int
+
+	var runs []byte
+
+	switch cookie := d.uint32(); {
+	case cookie == serialCookieNoRunContainer:
+		if size = int(d.uint32()); size > 1<<16 {
+			d.fail()
+		}
+	case cookie&0xFFFF == serialCookie:
+		size = int(cookie>>16) + 1
+		runs = make([]byte, (size+7)/8)
+		d.read(runs)
+	default:
+		d.fail()
+	}
+
+	if d.err != nil {
+		return nil
+	}
+
+	b := &Bitmap{
+		keys:       make([]uint16, size),
+		containers: make([]container, size),
+	}
+
+	cards := make([]int, size)
+
+	for i := range b.keys {
+		b.keys[i] = d.uint16()
+		cards[i] = int(d.uint16()) + 1
+
+		if i > 0 && b.keys[i] <= b.keys[i-1] {
+			d.fail()
+		}
+	}
+
+	if runs == nil || size >= noOffsetThreshold {
+		for i := 0; i < size; i++ {
+			_ = d.uint32() // Offsets are not needed when reading sequentially.
+		}
+	}
+
+	for i := range b.containers {
+		if d.err != nil {
+			return nil
+		}
+
+		switch {
+		case runs != nil && runs[i/8]&(1<<(i%8)) != 0:
+			b.containers[i] = d.readRunContainer(cards[i])
+		case cards[i] <= arrayMaxLength:
+			b.containers[i] = d.readArrayContainer(cards[i])
+		default:
+			b.containers[i] = d.readBitmapContainer(cards[i])
+		}
+	}
+
+	if d.err != nil {
+		return nil
+	}
+
+	return b
+}
+
+func (d *decoder) readArrayContainer(card int) container {
+	c := make(arrayContainer, card)
+
+	for i := range c {
+		c[i] = d.uint16()
+
+		if i > 0 && c[i] <= c[i-1] {
+			d.fail()
+		}
+	}
+
+	if d.err != nil {
+		return nil
+	}
+
+	return optimize(c)
+}
+
+func (d *decoder) readBitmapContainer(card int) container {
+	c := make(bitmapContainer, bitmapWords)
+	n := 0
+
+	for i := range c {
+		c[i] = d.uint64()
+		n += bits.OnesCount64(c[i])
+	}
+
+	if n != card {
+		d.fail()
+	}
+
+	if d.err != nil {
+		return nil
+	}
+
+	return optimize(c)
+}
+
+func (d *decoder) readRunContainer(card int) container {
+	nruns := int(d.uint16())
+	s := make(spans, 0, nruns)
+	n := 0
+
+	for i := 0; i < nruns; i++ {
+		start, length := uint32(d.uint16()), uint32(d.uint16())+1
+		r := intervals.Range(elems.Uint32(start), elems.Uint32(start+length))
+
+		if r.High > chunkSize {
+			d.fail()
+		}
+
+		if k := len(s); k != 0 {
+			switch c := s[k-1].High.Compare(r.Low); {
+			case c > 0:
+				d.fail()
+			case c == 0: // Adjacent runs.
+				s[k-1].High = r.High
+				n += int(length)
+
+				continue
+			}
+		}
+
+		s = append(s, r)
+		n += int(length)
+	}
+
+	if n != card {
+		d.fail()
+	}
+
+	if d.err != nil {
+		return nil
+	}
+
+	return newContainer(s)
+}
@@ -0,0 +1,200 @@
+package roaring_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/roaring"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	testCases := []struct {
+		Set intervals.Set[E]
+		Hex string
+	}{
+		{
+			nil,
+			"3a300000" + "00000000",
+		},
+		{
+			intervals.Collect(intervals.Range[E](1, 4)),
+			"3a300000" + "01000000" + "0000" + "0200" + "10000000" + "0100" + "0200" + "0300",
+		},
+		{
+			intervals.Collect(intervals.Range[E](0, 100)),
+			"3b300000" + "01" + "0000" + "6300" + "0100" + "0000" + "6300",
+		},
+		{
+			intervals.Collect(intervals.Unit[E](5), intervals.Range[E](1<<16, 1<<16+100)),
+			"3b300100" + "02" + "0000" + "0000" + "0100" + "6300" + "0500" + "0100" + "0000" + "6300",
+		},
+	}
+
+	for i, c := range testCases {
+		data, err := roaring.FromSet(c.Set).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := hex.EncodeToString(data); got != c.Hex {
+			t.Errorf("Case %v: want %v, but got %v", i, c.Hex, got)
+		}
+
+		var b roaring.Bitmap
+
+		if err := b.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Case %v: %v", i, err)
+		}
+
+		if got := roaring.ToSet[E](&b); !got.Equal(c.Set) {
+			t.Errorf("Case %v: want %v, but got %v", i, c.Set, got)
+		}
+	}
+}
+
+func TestSerializationRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		x := randomSet(rng)
+
+		var buf bytes.Buffer
+
+		if _, err := roaring.FromSet(x).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		buf.WriteString("trailing")
+
+		var b roaring.Bitmap
+
+		if _, err := b.ReadFrom(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := roaring.ToSet[E](&b); !got.Equal(x) {
+			t.Fatalf("want %v, but got %v", x, got)
+		}
+
+		if buf.String() != "trailing" {
+			t.Fatal("ReadFrom read too many bytes")
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	valid, _ := roaring.FromSet(intervals.Collect(intervals.Range[E](1, 4))).MarshalBinary()
+
+	testCases := []string{
+		"",
+		"3a30",
+		"39300000" + "00000000",
+		"3a300000" + "01000000" + "0000" + "0200" + "10000000" + "0100" + "0200",
+		"3a300000" + "01000000" + "0000" + "0200" + "10000000" + "0100" + "0100" + "0300",
+		"3a300000" + "02000000" + "0100" + "0000" + "0100" + "0000" + "14000000" + "16000000" + "0100" + "0100",
+		"3b300000" + "01" + "0000" + "6300" + "0100" + "0000" + "6400",
+		"3b300000" + "01" + "0000" + "0000" + "0100" + "ffff" + "0100",
+		hex.EncodeToString(valid) + "00",
+	}
+
+	for i, c := range testCases {
+		data, _ := hex.DecodeString(c)
+
+		var b roaring.Bitmap
+
+		if err := b.UnmarshalBinary(data); err == nil {
+			t.Errorf("Case %v: UnmarshalBinary didn't fail", i)
+		}
+	}
+
+	var b roaring.Bitmap
+
+	if _, err := b.ReadFrom(bytes.NewReader(valid[:5])); err != io.ErrUnexpectedEOF {
+		t.Errorf("want %v, but got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestBitmap64(t *testing.T) {
+	type E = elems.Uint64
+
+	x := intervals.Collect(
+		intervals.Range[E](1, 3),
+		intervals.Range[E](1<<32-5, 1<<32+5),
+		intervals.Range[E](3<<32, 5<<32+1),
+		intervals.Range[E](1<<64-10, 1<<64-1),
+	)
+
+	b := roaring.FromSet64(x)
+
+	assertions := []bool{
+		roaring.ToSet64[E](b).Equal(x),
+		b.Cardinality() == 2+10+(2<<32+1)+9,
+		b.Contains(1),
+		!b.Contains(3),
+		b.Contains(1<<32 - 1),
+		b.Contains(1 << 32),
+		b.Contains(4<<32 + 12345),
+		b.Contains(5 << 32),
+		!b.Contains(5<<32 + 1),
+		b.Contains(1<<64 - 2),
+		!b.Contains(1<<64 - 1),
+		roaring.ToSet64[elems.Uint32](b).Equal(intervals.Collect(intervals.Range[elems.Uint32](1, 3), intervals.Range[elems.Uint32](1<<32-5, 1<<32-1))),
+		roaring.ToSet64[E](new(roaring.Bitmap64)) == nil,
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b2 roaring.Bitmap64
+
+	if err := b2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := roaring.ToSet64[E](&b2); !got.Equal(x) {
+		t.Fatalf("want %v, but got %v", x, got)
+	}
+
+	var buf bytes.Buffer
+
+	if _, err := b.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("WriteTo and MarshalBinary didn't agree")
+	}
+
+	empty, _ := new(roaring.Bitmap64).MarshalBinary()
+
+	if got := hex.EncodeToString(empty); got != "0000000000000000" {
+		t.Fatalf("want %v, but got %v", "0000000000000000", got)
+	}
+
+	for _, c := range []string{
+		"",
+		"0100000000000000",
+		"0200000000000000" + "01000000" + "3a30000000000000" + "01000000" + "3a30000000000000",
+		hex.EncodeToString(data) + "00",
+	} {
+		data, _ := hex.DecodeString(c)
+
+		if err := b2.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) didn't fail", c)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package runes provides conversions between sets of runes and
+// [unicode.RangeTable].
+package runes
+
+import (
+	"unicode"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// A Set is a set of runes.
+type Set = intervals.Set[elems.Int32]
+
+// FromRangeTable returns the set of runes that are in tab.
+func FromRangeTable(tab *unicode.RangeTable) Set {
+	s := appendRangeTable(nil, tab)
+	return intervals.CollectInto(s, s...)
+}
+
+// FromRanges returns the set of runes that are in any of tabs.
+func FromRanges(tabs ...*unicode.RangeTable) Set {
+	var s []intervals.Interval[elems.Int32]
+
+	for _, tab := range tabs {
+		s = appendRangeTable(s, tab)
+	}
+
+	return intervals.CollectInto(s, s...)
+}
+
+func appendRangeTable(s []intervals.Interval[elems.Int32], tab *unicode.RangeTable) []intervals.Interval[elems.Int32] {
+	for _, r := range tab.R16 {
+		s = appendStride(s, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+
+	for _, r := range tab.R32 {
+		s = appendStride(s, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+
+	return s
+}
+
+func appendStride(s []intervals.Interval[elems.Int32], lo, hi, stride rune) []intervals.Interval[elems.Int32] {
+	if stride == 1 {
+		return append(s, intervals.Range(elems.Int32(lo), elems.Int32(hi+1)))
+	}
+
+	for v := lo; v <= hi; v += stride {
+		s = append(s, intervals.Unit(elems.Int32(v)))
+	}
+
+	return s
+}
+
+// ToRangeTable returns a [unicode.RangeTable] that contains the same runes
+// as x. Elements of x that are not valid Unicode code points, i.e. negative
+// numbers or numbers greater than [unicode.MaxRune], are ignored.
+//
+// Evenly spaced single runes are merged into ranges with a Stride greater
+// than 1.
+func ToRangeTable(x Set) *unicode.RangeTable {
+	var tab unicode.RangeTable
+
+	r16 := x.Intersection(intervals.Range[elems.Int32](0, 1<<16).Set())
+	r32 := x.Intersection(intervals.Range[elems.Int32](1<<16, unicode.MaxRune+1).Set())
+
+	forEachStride(r16, func(lo, hi, stride rune) {
+		tab.R16 = append(tab.R16, unicode.Range16{Lo: uint16(lo), Hi: uint16(hi), Stride: uint16(stride)})
+
+		if hi <= unicode.MaxLatin1 {
+			tab.LatinOffset++
+		}
+	})
+
+	forEachStride(r32, func(lo, hi, stride rune) {
+		tab.R32 = append(tab.R32, unicode.Range32{Lo: uint32(lo), Hi: uint32(hi), Stride: uint32(stride)})
+	})
+
+	return &tab
+}
+
+// forEachStride calls f for each range [lo, hi] with stride that x consists
+// of, in ascending order.
+func forEachStride(x Set, f func(lo, hi, stride rune)) {
+	for len(x) != 0 {
+		r := x[0]
+		lo, hi := rune(r.Low), rune(r.High)
+
+		if hi-lo > 1 || len(x) == 1 || x[1].High-x[1].Low > 1 {
+			f(lo, hi-1, 1)
+			x = x[1:]
+
+			continue
+		}
+
+		stride := rune(x[1].Low) - lo
+		n := 2
+
+		for n < len(x) && x[n].High-x[n].Low == 1 && rune(x[n].Low)-rune(x[n-1].Low) == stride {
+			n++
+		}
+
+		f(lo, rune(x[n-1].Low), stride)
+		x = x[n:]
+	}
+}
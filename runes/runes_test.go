@@ -0,0 +1,102 @@
+package runes_test
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/runes"
+)
+
+func TestFromRangeTable(t *testing.T) {
+	for _, tab := range []*unicode.RangeTable{unicode.Letter, unicode.Digit, unicode.Upper, unicode.Han} {
+		x := runes.FromRangeTable(tab)
+
+		for r := rune(0); r <= unicode.MaxRune; r++ {
+			if x.ContainsUnit(elems.Int32(r)) != unicode.Is(tab, r) {
+				t.Fatalf("rune %U: want %v, but got %v", r, unicode.Is(tab, r), !unicode.Is(tab, r))
+			}
+		}
+	}
+}
+
+func TestFromRanges(t *testing.T) {
+	x := runes.FromRanges(unicode.Letter, unicode.Digit)
+	y := runes.FromRangeTable(unicode.Letter).Union(runes.FromRangeTable(unicode.Digit))
+
+	if !x.Equal(y) {
+		t.Fatalf("want %v, but got %v", y, x)
+	}
+
+	if z := runes.FromRanges(); len(z) != 0 {
+		t.Fatalf("want empty set, but got %v", z)
+	}
+}
+
+func TestToRangeTable(t *testing.T) {
+	for _, tab := range []*unicode.RangeTable{unicode.Letter, unicode.Upper, unicode.Lower, unicode.Han} {
+		x := runes.FromRangeTable(tab)
+		tab2 := runes.ToRangeTable(x)
+
+		if y := runes.FromRangeTable(tab2); !y.Equal(x) {
+			t.Fatalf("round trip failed: want %v, but got %v", x, y)
+		}
+
+		for r := rune(0); r <= unicode.MaxRune; r++ {
+			if unicode.Is(tab2, r) != unicode.Is(tab, r) {
+				t.Fatalf("rune %U: want %v, but got %v", r, unicode.Is(tab, r), !unicode.Is(tab, r))
+			}
+		}
+	}
+
+	type E = elems.Int32
+
+	x := intervals.Collect(
+		intervals.Range[E](-5, 3),
+		intervals.Unit[E]('a'), intervals.Unit[E]('c'), intervals.Unit[E]('e'),
+		intervals.Range[E](0xFFF0, 0x10010),
+		intervals.Unit[E](0x20000), intervals.Unit[E](0x20004),
+		intervals.Range[E](unicode.MaxRune, unicode.MaxRune+5),
+	)
+
+	tab := runes.ToRangeTable(x)
+
+	want := &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0, Hi: 2, Stride: 1},
+			{Lo: 'a', Hi: 'e', Stride: 2},
+			{Lo: 0xFFF0, Hi: 0xFFFF, Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x10000, Hi: 0x1000F, Stride: 1},
+			{Lo: 0x20000, Hi: 0x20004, Stride: 4},
+			{Lo: unicode.MaxRune, Hi: unicode.MaxRune, Stride: 1},
+		},
+		LatinOffset: 2,
+	}
+
+	if !equalRangeTable(tab, want) {
+		t.Fatalf("want %v, but got %v", want, tab)
+	}
+}
+
+func equalRangeTable(a, b *unicode.RangeTable) bool {
+	if len(a.R16) != len(b.R16) || len(a.R32) != len(b.R32) || a.LatinOffset != b.LatinOffset {
+		return false
+	}
+
+	for i := range a.R16 {
+		if a.R16[i] != b.R16[i] {
+			return false
+		}
+	}
+
+	for i := range a.R32 {
+		if a.R32[i] != b.R32[i] {
+			return false
+		}
+	}
+
+	return true
+}
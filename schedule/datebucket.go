@@ -0,0 +1,62 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// A DateBucket is a calendar period, along with the days of a set that fall
+// within it.
+type DateBucket struct {
+	Period intervals.Interval[elems.Date] // The whole week or month.
+	Set    intervals.Set[elems.Date]      // Days in both the period and the set.
+}
+
+// ByWeek splits x into weeks that start on start, returning one DateBucket
+// for each week that contains at least one day of x, in ascending order.
+func ByWeek(x intervals.Set[elems.Date], start time.Weekday) []DateBucket {
+	return bucketize(x, func(d elems.Date) intervals.Interval[elems.Date] {
+		lo := d.AddDays(-((int(d.Weekday()) - int(start) + 7) % 7))
+		return intervals.Range(lo, lo.AddDays(7))
+	})
+}
+
+// ByMonth splits x into calendar months, returning one DateBucket for each
+// month that contains at least one day of x, in ascending order.
+func ByMonth(x intervals.Set[elems.Date]) []DateBucket {
+	return bucketize(x, func(d elems.Date) intervals.Interval[elems.Date] {
+		lo := elems.Date{Year: d.Year, Month: d.Month, Day: 1}
+		return intervals.Range(lo, elems.DateOf(time.Date(d.Year, d.Month+1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+}
+
+// bucketize splits x by periods, where period returns the period that
+// contains a given day.
+func bucketize(x intervals.Set[elems.Date], period func(elems.Date) intervals.Interval[elems.Date]) []DateBucket {
+	var buckets []DateBucket
+
+	for _, r := range x {
+		for r.Low.Compare(r.High) < 0 {
+			n := len(buckets)
+
+			if n == 0 || r.Low.Compare(buckets[n-1].Period.High) >= 0 {
+				buckets = append(buckets, DateBucket{Period: period(r.Low)})
+				n++
+			}
+
+			b := &buckets[n-1]
+			hi := r.High
+
+			if hi.Compare(b.Period.High) > 0 {
+				hi = b.Period.High
+			}
+
+			b.Set = append(b.Set, intervals.Range(r.Low, hi))
+			r.Low = hi
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,71 @@
+package schedule_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/schedule"
+)
+
+func TestDateBuckets(t *testing.T) {
+	date := func(s string) elems.Date {
+		d, err := elems.ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return d
+	}
+
+	interval := func(lo, hi string) intervals.Interval[elems.Date] {
+		return intervals.Range(date(lo), date(hi))
+	}
+
+	x := intervals.Collect(
+		interval("2024-01-30", "2024-02-03"),
+		interval("2024-02-05", "2024-02-06"),
+		interval("2024-03-31", "2024-04-01"),
+	)
+
+	testCases := []struct {
+		Actual, Expected []schedule.DateBucket
+	}{
+		{
+			schedule.ByMonth(x),
+			[]schedule.DateBucket{
+				{interval("2024-01-01", "2024-02-01"), intervals.Set[elems.Date]{interval("2024-01-30", "2024-02-01")}},
+				{interval("2024-02-01", "2024-03-01"), intervals.Set[elems.Date]{interval("2024-02-01", "2024-02-03"), interval("2024-02-05", "2024-02-06")}},
+				{interval("2024-03-01", "2024-04-01"), intervals.Set[elems.Date]{interval("2024-03-31", "2024-04-01")}},
+			},
+		},
+		{
+			schedule.ByWeek(x, time.Monday),
+			[]schedule.DateBucket{
+				{interval("2024-01-29", "2024-02-05"), intervals.Set[elems.Date]{interval("2024-01-30", "2024-02-03")}},
+				{interval("2024-02-05", "2024-02-12"), intervals.Set[elems.Date]{interval("2024-02-05", "2024-02-06")}},
+				{interval("2024-03-25", "2024-04-01"), intervals.Set[elems.Date]{interval("2024-03-31", "2024-04-01")}},
+			},
+		},
+		{
+			schedule.ByWeek(x, time.Sunday),
+			[]schedule.DateBucket{
+				{interval("2024-01-28", "2024-02-04"), intervals.Set[elems.Date]{interval("2024-01-30", "2024-02-03")}},
+				{interval("2024-02-04", "2024-02-11"), intervals.Set[elems.Date]{interval("2024-02-05", "2024-02-06")}},
+				{interval("2024-03-31", "2024-04-07"), intervals.Set[elems.Date]{interval("2024-03-31", "2024-04-01")}},
+			},
+		},
+		{schedule.ByMonth(nil), nil},
+	}
+
+	for i, c := range testCases {
+		if !slices.EqualFunc(c.Actual, c.Expected, func(x, y schedule.DateBucket) bool {
+			return x.Period.Equal(y.Period) && x.Set.Equal(y.Set)
+		}) {
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+			t.Fail()
+		}
+	}
+}
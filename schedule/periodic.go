@@ -0,0 +1,112 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// A Periodic is an unbounded set of times that repeats itself every period,
+// for example, business hours "every weekday from 9 to 17 o'clock".
+//
+// Periods are of a fixed duration; a Periodic does not adjust itself to
+// daylight saving time transitions.
+type Periodic struct {
+	epoch  time.Time
+	period time.Duration
+	base   Set
+}
+
+// NewPeriodic returns a Periodic that repeats base, which is clipped to
+// the period starting at epoch, every period. NewPeriodic panics if period
+// is not positive.
+//
+// For example, given a Monday midnight m in location loc, the following
+// code returns business hours in loc:
+//
+//	week := intervals.Range(elems.Time(m), elems.Time(m.AddDate(0, 0, 7)))
+//	base := WorkingHours(week, loc, 9*time.Hour, 17*time.Hour,
+//		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+//	p := NewPeriodic(m, 7*24*time.Hour, base)
+func NewPeriodic(epoch time.Time, period time.Duration, base Set) Periodic {
+	if period <= 0 {
+		panic("non-positive period")
+	}
+
+	first := intervals.Range(elems.Time(epoch), elems.Time(epoch.Add(period)))
+
+	return Periodic{epoch, period, base.Intersection(first.Set())}
+}
+
+// Period returns the period of p.
+func (p Periodic) Period() time.Duration {
+	return p.period
+}
+
+// Base returns the set of times in the period starting at the epoch of p.
+// The returned Set is shared and must not be modified.
+func (p Periodic) Base() Set {
+	return p.base
+}
+
+// ContainsUnit reports whether p contains a single time v.
+func (p Periodic) ContainsUnit(v elems.Time) bool {
+	t := time.Time(v)
+	return p.base.ContainsUnit(elems.Time(t.Add(-p.periods(t) * p.period)))
+}
+
+// periods returns the number of whole periods from the epoch of p to t,
+// rounded towards negative infinity.
+func (p Periodic) periods(t time.Time) time.Duration {
+	d := t.Sub(p.epoch)
+
+	k := d / p.period
+	if d%p.period < 0 {
+		k--
+	}
+
+	return k
+}
+
+// Materialize returns the set of times in window that are in p.
+func (p Periodic) Materialize(window Interval) Set {
+	if window.Low.Compare(window.High) >= 0 || len(p.base) == 0 {
+		return nil
+	}
+
+	var s []Interval
+
+	lo, hi := time.Time(window.Low), time.Time(window.High)
+
+	for k := p.periods(lo); ; k++ {
+		shift := k * p.period
+		if !p.epoch.Add(shift).Before(hi) {
+			break
+		}
+
+		for _, r := range p.base {
+			s = append(s, intervals.Range(
+				elems.Time(time.Time(r.Low).Add(shift)),
+				elems.Time(time.Time(r.High).Add(shift)),
+			))
+		}
+	}
+
+	return intervals.Collect(s...).Intersection(window.Set())
+}
+
+// Union returns the set of times in window that are in either p or x.
+func (p Periodic) Union(x Set, window Interval) Set {
+	return p.Materialize(window).Union(x.Intersection(window.Set()))
+}
+
+// Intersection returns the set of times in window that are in both p and x.
+func (p Periodic) Intersection(x Set, window Interval) Set {
+	return p.Materialize(window).Intersection(x)
+}
+
+// Difference returns the set of times in window that are in p, but not in x.
+func (p Periodic) Difference(x Set, window Interval) Set {
+	return p.Materialize(window).Difference(x)
+}
@@ -0,0 +1,111 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/schedule"
+)
+
+func businessHours() schedule.Periodic {
+	week := intervals.Range(elems.Time(day), elems.Time(day.AddDate(0, 0, 7)))
+	base := schedule.WorkingHours(week, time.UTC, 9*time.Hour, 17*time.Hour,
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+	return schedule.NewPeriodic(day, 7*24*time.Hour, base)
+}
+
+func TestPeriodicContainsUnit(t *testing.T) {
+	p := businessHours()
+
+	testCases := []struct {
+		Time     time.Time
+		Expected bool
+	}{
+		{day, false},
+		{day.Add(9 * time.Hour), true},
+		{day.Add(17*time.Hour - 1), true},
+		{day.Add(17 * time.Hour), false},
+		{day.AddDate(0, 0, 5).Add(12 * time.Hour), false}, // Saturday.
+		{day.AddDate(0, 0, 14).Add(12 * time.Hour), true},
+		{day.AddDate(0, 0, -7).Add(12 * time.Hour), true},
+		{day.AddDate(0, 0, -1).Add(12 * time.Hour), false}, // Sunday.
+		{day.AddDate(0, 0, -3).Add(12 * time.Hour), true},  // Friday.
+		{day.AddDate(-3, 0, 0).Add(12 * time.Hour), true},  // Monday.
+	}
+
+	for i, c := range testCases {
+		if p.ContainsUnit(elems.Time(c.Time)) != c.Expected {
+			t.Logf("Case %v: want %v for %v", i, c.Expected, c.Time)
+			t.Fail()
+		}
+	}
+
+	if p.Period() != 7*24*time.Hour || len(p.Base()) != 5 {
+		t.Fatalf("got %v, %v", p.Period(), p.Base())
+	}
+}
+
+func TestPeriodicMaterialize(t *testing.T) {
+	p := businessHours()
+
+	// From Friday noon to next Monday noon.
+	window := r(-3*24+12, 0, 12, 0)
+	x := p.Materialize(window)
+
+	if !x.Equal(set(r(-3*24+12, 0, -3*24+17, 0), r(9, 0, 12, 0))) {
+		t.Fatalf("got %v", x)
+	}
+
+	year := intervals.Range(elems.Time(day), elems.Time(day.AddDate(1, 0, 0)))
+	if n := len(p.Materialize(year)); n != 261 {
+		t.Fatalf("want 261 business days, but got %v", n)
+	}
+
+	if y := p.Materialize(r(12, 0, 12, 0)); len(y) != 0 {
+		t.Fatalf("want empty set, but got %v", y)
+	}
+
+	empty := schedule.NewPeriodic(day, time.Hour, nil)
+	if y := empty.Materialize(year); len(y) != 0 {
+		t.Fatalf("want empty set, but got %v", y)
+	}
+}
+
+func TestPeriodicOps(t *testing.T) {
+	p := businessHours()
+	window := r(0, 0, 24, 0)
+	busy := set(r(8, 0, 10, 0), r(16, 0, 18, 0), r(30, 0, 31, 0))
+
+	testCases := []struct {
+		Actual, Expected schedule.Set
+	}{
+		{p.Union(busy, window), set(r(8, 0, 18, 0))},
+		{p.Intersection(busy, window), set(r(9, 0, 10, 0), r(16, 0, 17, 0))},
+		{p.Difference(busy, window), set(r(10, 0, 16, 0))},
+	}
+
+	for i, c := range testCases {
+		if !c.Actual.Equal(c.Expected) {
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestNewPeriodic(t *testing.T) {
+	p := schedule.NewPeriodic(day, time.Hour, set(r(0, 30, 2, 0)))
+	if !p.Base().Equal(set(r(0, 30, 1, 0))) {
+		t.Fatalf("want base clipped to the first period, but got %v", p.Base())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want panic")
+		}
+	}()
+
+	schedule.NewPeriodic(day, 0, nil)
+}
@@ -0,0 +1,214 @@
+// Package schedule finds time slots in which participants of a meeting are
+// free, based on Sets of times during which each participant is busy, and
+// groups Sets of dates by calendar weeks or months.
+package schedule
+
+import (
+	"math/bits"
+	"slices"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+// Set is a set of times.
+type Set = intervals.Set[elems.Time]
+
+// Interval is a range of times.
+type Interval = intervals.Interval[elems.Time]
+
+// WorkingHours returns the set of times from start to end after midnight,
+// in wall clock time of location loc, on every day that overlaps window and
+// whose weekday is one of days, or on every day if days is empty.
+// The result is clipped to window.
+//
+// For example, WorkingHours(w, loc, 9*time.Hour, 17*time.Hour,
+// time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+// returns weekdays from 9 to 17 o'clock.
+func WorkingHours(window Interval, loc *time.Location, start, end time.Duration, days ...time.Weekday) Set {
+	if window.Low.Compare(window.High) >= 0 || start >= end {
+		return nil
+	}
+
+	var s []Interval
+
+	first := elems.DateOf(time.Time(window.Low).In(loc)).AddDays(-1)
+	last := elems.DateOf(time.Time(window.High).In(loc))
+
+	for d := first; d.Compare(last) <= 0; d = d.Next() {
+		if len(days) != 0 && !slices.Contains(days, d.Weekday()) {
+			continue
+		}
+
+		lo, hi := wallClock(d, start, loc), wallClock(d, end, loc)
+		s = append(s, intervals.Range(elems.Time(lo), elems.Time(hi)))
+	}
+
+	return intervals.Collect(s...).Intersection(window.Set())
+}
+
+// wallClock returns the time that is d after midnight on day, in wall clock
+// time of location loc.
+func wallClock(day elems.Date, d time.Duration, loc *time.Location) time.Time {
+	// Split d so that each argument of time.Date fits in an int, even on
+	// 32-bit platforms.
+	h, m, s, ns := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second, d%time.Second
+
+	return time.Date(day.Year, day.Month, day.Day, int(h), int(m), int(s), int(ns), loc)
+}
+
+// Free returns the set of times in within during which none of busy is.
+func Free(within Set, busy ...Set) Set {
+	return within.Difference(intervals.Reduce(intervals.Union[elems.Time], busy...))
+}
+
+// A Slot is a candidate time slot for a meeting.
+type Slot struct {
+	Interval
+
+	// Available holds, in ascending order, indices of optional participants
+	// who are free during the whole slot.
+	Available []int
+}
+
+// Duration returns the length of s.
+func (s Slot) Duration() time.Duration {
+	return time.Time(s.High).Sub(time.Time(s.Low))
+}
+
+// FindSlots returns candidate slots of at least minDuration long, within
+// within, during which all required participants are free. required and
+// optional hold Sets of times during which each participant is busy.
+//
+// Each candidate slot is a longest possible slot for a group of optional
+// participants to be available, so candidate slots may overlap one another.
+// Candidate slots are ranked by the number of available optional
+// participants, most first, then by start time, earliest first, then by
+// duration, longest first.
+func FindSlots(within Set, required, optional []Set, minDuration time.Duration) []Slot {
+	var slots []Slot
+
+	for _, run := range Free(within, required...) {
+		slots = appendSlots(slots, run, optional, minDuration)
+	}
+
+	slices.SortStableFunc(slots, func(a, b Slot) int {
+		if c := len(b.Available) - len(a.Available); c != 0 {
+			return c
+		}
+
+		if c := a.Low.Compare(b.Low); c != 0 {
+			return c
+		}
+
+		return b.High.Compare(a.High)
+	})
+
+	return slots
+}
+
+// appendSlots appends to slots candidate slots within run, in which all
+// required participants are free.
+func appendSlots(slots []Slot, run Interval, optional []Set, minDuration time.Duration) []Slot {
+	// Split run into segments, in each of which availability of optional
+	// participants does not change.
+	bounds := []elems.Time{run.Low, run.High}
+
+	for _, busy := range optional {
+		for _, r := range busy.Intersection(run.Set()) {
+			bounds = append(bounds, r.Low, r.High)
+		}
+	}
+
+	slices.SortFunc(bounds, elems.Time.Compare)
+	bounds = slices.CompactFunc(bounds, func(a, b elems.Time) bool { return a.Compare(b) == 0 })
+
+	segs := make([]bitset, len(bounds)-1)
+	for i := range segs {
+		segs[i] = newBitset(len(optional))
+
+		for j, busy := range optional {
+			if !busy.ContainsUnit(bounds[i]) {
+				segs[i].set(j)
+			}
+		}
+	}
+
+	// For each segment i, find slots starting from i that cannot extend to
+	// the left without losing any available optional participant.
+	for i := range segs {
+		avail := slices.Clone(segs[i])
+
+		for j := i; j < len(segs); j++ {
+			avail.and(segs[j])
+
+			if j+1 < len(segs) && avail.subsetOf(segs[j+1]) {
+				continue // Can extend to the right.
+			}
+
+			if i > 0 && avail.subsetOf(segs[i-1]) {
+				continue // Can extend to the left.
+			}
+
+			s := Slot{Interval: intervals.Range(bounds[i], bounds[j+1]), Available: avail.indices()}
+			if s.Duration() >= minDuration {
+				slots = append(slots, s)
+			}
+
+			if avail.empty() {
+				break
+			}
+		}
+	}
+
+	return slots
+}
+
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) and(c bitset) {
+	for i := range b {
+		b[i] &= c[i]
+	}
+}
+
+func (b bitset) subsetOf(c bitset) bool {
+	for i := range b {
+		if b[i]&^c[i] != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b bitset) empty() bool {
+	for _, w := range b {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b bitset) indices() []int {
+	var s []int
+
+	for i, w := range b {
+		for ; w != 0; w &= w - 1 {
+			s = append(s, i*64+bits.TrailingZeros64(w))
+		}
+	}
+
+	return s
+}
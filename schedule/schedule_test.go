@@ -0,0 +1,145 @@
+package schedule_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/schedule"
+)
+
+var day = time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC) // A Monday.
+
+func at(h, m int) elems.Time {
+	return elems.Time(day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute))
+}
+
+func r(h1, m1, h2, m2 int) schedule.Interval {
+	return intervals.Range(at(h1, m1), at(h2, m2))
+}
+
+func set(s ...schedule.Interval) schedule.Set {
+	return intervals.Collect(s...)
+}
+
+func TestWorkingHours(t *testing.T) {
+	week := intervals.Range(elems.Time(day), elems.Time(day.AddDate(0, 0, 7)))
+	x := schedule.WorkingHours(week, time.UTC, 9*time.Hour, 17*time.Hour,
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+	if len(x) != 5 {
+		t.Fatalf("want 5 intervals, but got %v", x)
+	}
+
+	for i, r := range x {
+		d := day.AddDate(0, 0, i)
+		if !r.Equal(intervals.Range(elems.Time(d.Add(9*time.Hour)), elems.Time(d.Add(17*time.Hour)))) {
+			t.Errorf("day %v: got %v", i, r)
+		}
+	}
+
+	// Clipped to window.
+	y := schedule.WorkingHours(r(12, 0, 36, 0), time.UTC, 9*time.Hour, 17*time.Hour)
+	if !y.Equal(set(r(12, 0, 17, 0), r(33, 0, 36, 0))) {
+		t.Errorf("want clipped intervals, but got %v", y)
+	}
+
+	// Bounds with every unit of a Duration.
+	offset := 9*time.Hour + 30*time.Minute + 15*time.Second + 500*time.Millisecond
+	u := schedule.WorkingHours(r(0, 0, 24, 0), time.UTC, offset, 17*time.Hour+time.Nanosecond)
+
+	if want := intervals.Range(elems.Time(day.Add(offset)), elems.Time(day.Add(17*time.Hour+time.Nanosecond))); len(u) != 1 || !u[0].Equal(want) {
+		t.Errorf("want %v, but got %v", want, u)
+	}
+
+	// Wall clock time across a daylight saving time transition.
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	lo := time.Date(2024, time.March, 9, 0, 0, 0, 0, loc)
+	hi := time.Date(2024, time.March, 11, 0, 0, 0, 0, loc)
+	z := schedule.WorkingHours(intervals.Range(elems.Time(lo), elems.Time(hi)), loc, 9*time.Hour, 17*time.Hour)
+
+	if len(z) != 2 {
+		t.Fatalf("want 2 intervals, but got %v", z)
+	}
+
+	for _, r := range z {
+		lo, hi := time.Time(r.Low).In(loc), time.Time(r.High).In(loc)
+		if lo.Hour() != 9 || lo.Minute() != 0 || hi.Hour() != 17 || hi.Minute() != 0 {
+			t.Errorf("want from 9 to 17 o'clock, but got %v - %v", lo, hi)
+		}
+	}
+}
+
+func TestFree(t *testing.T) {
+	within := set(r(9, 0, 17, 0))
+	alice := set(r(10, 0, 11, 0))
+	bob := set(r(10, 30, 12, 0), r(16, 0, 18, 0))
+
+	x := schedule.Free(within, alice, bob)
+	if !x.Equal(set(r(9, 0, 10, 0), r(12, 0, 16, 0))) {
+		t.Fatalf("got %v", x)
+	}
+
+	if y := schedule.Free(within); !y.Equal(within) {
+		t.Fatalf("got %v", y)
+	}
+}
+
+func TestFindSlots(t *testing.T) {
+	within := set(r(9, 0, 17, 0))
+	required := []schedule.Set{
+		set(r(10, 0, 11, 0)),
+		set(r(12, 0, 13, 0), r(16, 30, 17, 0)),
+	}
+	optional := []schedule.Set{
+		set(r(9, 0, 9, 30), r(14, 0, 15, 0)),
+		set(r(15, 30, 16, 0)),
+	}
+
+	slots := schedule.FindSlots(within, required, optional, 30*time.Minute)
+
+	type result struct {
+		r     schedule.Interval
+		avail []int
+	}
+
+	expected := []result{
+		{r(9, 30, 10, 0), []int{0, 1}},
+		{r(11, 0, 12, 0), []int{0, 1}},
+		{r(13, 0, 14, 0), []int{0, 1}},
+		{r(15, 0, 15, 30), []int{0, 1}},
+		{r(16, 0, 16, 30), []int{0, 1}},
+		{r(9, 0, 10, 0), []int{1}},
+		{r(13, 0, 15, 30), []int{1}},
+		{r(15, 0, 16, 30), []int{0}},
+		{r(13, 0, 16, 30), nil},
+	}
+
+	if len(slots) != len(expected) {
+		t.Fatalf("want %v slots, but got %v", len(expected), slots)
+	}
+
+	for i, s := range slots {
+		if !s.Equal(expected[i].r) || !slices.Equal(s.Available, expected[i].avail) {
+			t.Errorf("Case %v: want %v %v, but got %v %v", i, expected[i].r, expected[i].avail, s.Interval, s.Available)
+		}
+	}
+
+	if d := slots[0].Duration(); d != 30*time.Minute {
+		t.Errorf("want 30m, but got %v", d)
+	}
+
+	if s := schedule.FindSlots(within, required, nil, 2*time.Hour); len(s) != 1 || !s[0].Equal(r(13, 0, 16, 30)) {
+		t.Errorf("got %v", s)
+	}
+
+	if s := schedule.FindSlots(within, required, nil, 4*time.Hour); len(s) != 0 {
+		t.Errorf("want no slots, but got %v", s)
+	}
+}
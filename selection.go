@@ -0,0 +1,107 @@
+package intervals
+
+import (
+	"slices"
+	"sort"
+)
+
+// Number is the type set of numeric types, used as weights.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// MaxDisjoint returns indices of a largest subset of s in which intervals
+// are pairwise disjoint, in ascending order of the intervals.
+// Adjacent intervals, such as [1, 3) and [3, 5), are disjoint.
+// Empty or invalid intervals in s are never selected.
+//
+// MaxDisjoint uses the earliest-finish-time greedy algorithm, which runs in
+// O(n log n) time.
+func MaxDisjoint[E Elem[E]](s []Interval[E]) []int {
+	order := sortedByHigh(s)
+
+	var selected []int
+
+	for _, i := range order {
+		if len(selected) == 0 || s[selected[len(selected)-1]].High.Compare(s[i].Low) <= 0 {
+			selected = append(selected, i)
+		}
+	}
+
+	return selected
+}
+
+// MaxWeightDisjoint returns indices of a subset of s in which intervals are
+// pairwise disjoint and whose total weight is maximal, in ascending order of
+// the intervals, along with the total weight. weights[i] is the weight of
+// s[i]; intervals with non-positive weights are never selected.
+// Adjacent intervals, such as [1, 3) and [3, 5), are disjoint.
+// Empty or invalid intervals in s are never selected.
+//
+// MaxWeightDisjoint panics if len(weights) != len(s).
+//
+// MaxWeightDisjoint uses dynamic programming, which runs in O(n log n) time.
+func MaxWeightDisjoint[E Elem[E], W Number](s []Interval[E], weights []W) ([]int, W) {
+	if len(weights) != len(s) {
+		panic("len(weights) != len(s)")
+	}
+
+	order := sortedByHigh(s)
+	n := len(order)
+
+	// best[j] is the maximal total weight of intervals in order[:j].
+	// prev[j] is the number of intervals in order that end before
+	// order[j-1] starts.
+	best := make([]W, n+1)
+	prev := make([]int, n+1)
+
+	for j := 1; j <= n; j++ {
+		r := s[order[j-1]]
+		prev[j] = sort.Search(j-1, func(i int) bool { return s[order[i]].High.Compare(r.Low) > 0 })
+
+		best[j] = best[j-1]
+		if w := weights[order[j-1]] + best[prev[j]]; w > best[j] {
+			best[j] = w
+		}
+	}
+
+	var selected []int
+
+	for j := n; j > 0; {
+		if best[j] == best[j-1] {
+			j--
+			continue
+		}
+
+		selected = append(selected, order[j-1])
+		j = prev[j]
+	}
+
+	slices.Reverse(selected)
+
+	return selected, best[n]
+}
+
+// sortedByHigh returns indices of non-empty valid intervals in s, sorted by
+// High, then by Low.
+func sortedByHigh[E Elem[E]](s []Interval[E]) []int {
+	order := make([]int, 0, len(s))
+
+	for i, r := range s {
+		if r.Low.Compare(r.High) < 0 {
+			order = append(order, i)
+		}
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		if c := s[i].High.Compare(s[j].High); c != 0 {
+			return c
+		}
+
+		return s[i].Low.Compare(s[j].Low)
+	})
+
+	return order
+}
@@ -0,0 +1,135 @@
+package intervals_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestMaxDisjoint(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Actual, Expected []int
+	}{
+		{MaxDisjoint[E](nil), nil},
+		{MaxDisjoint([]Interval[E]{{1, 3}}), []int{0}},
+		{MaxDisjoint([]Interval[E]{{3, 5}, {1, 3}}), []int{1, 0}},
+		{MaxDisjoint([]Interval[E]{{1, 10}, {2, 4}, {4, 6}, {6, 8}}), []int{1, 2, 3}},
+		{MaxDisjoint([]Interval[E]{{1, 4}, {3, 6}, {5, 8}, {7, 10}}), []int{0, 2}},
+		{MaxDisjoint([]Interval[E]{{1, 3}, {1, 3}, {5, 5}, {7, 6}}), []int{0}},
+	}
+
+	for i, c := range testCases {
+		if !slices.Equal(c.Actual, c.Expected) {
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestMaxWeightDisjoint(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Intervals []Interval[E]
+		Weights   []int
+		Expected  []int
+		Total     int
+	}{
+		{nil, nil, nil, 0},
+		{[]Interval[E]{{1, 3}}, []int{5}, []int{0}, 5},
+		{[]Interval[E]{{1, 3}}, []int{0}, nil, 0},
+		{[]Interval[E]{{1, 3}}, []int{-1}, nil, 0},
+		{[]Interval[E]{{1, 10}, {2, 4}, {4, 6}, {6, 8}}, []int{10, 3, 3, 3}, []int{0}, 10},
+		{[]Interval[E]{{1, 10}, {2, 4}, {4, 6}, {6, 8}}, []int{8, 3, 3, 3}, []int{1, 2, 3}, 9},
+		{[]Interval[E]{{1, 4}, {3, 6}, {5, 8}, {7, 10}}, []int{1, 5, 1, 5}, []int{1, 3}, 10},
+		{[]Interval[E]{{5, 5}, {1, 3}}, []int{100, 1}, []int{1}, 1},
+	}
+
+	for i, c := range testCases {
+		selected, total := MaxWeightDisjoint(c.Intervals, c.Weights)
+		if !slices.Equal(selected, c.Expected) || total != c.Total {
+			t.Logf("Case %v: want %v %v, but got %v %v", i, c.Expected, c.Total, selected, total)
+			t.Fail()
+		}
+	}
+
+	shouldPanic(t, func() { MaxWeightDisjoint([]Interval[E]{{1, 3}}, []int{}) }, "MaxWeightDisjoint")
+}
+
+func TestDisjointRandom(t *testing.T) {
+	type E = elems.Int
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 500; n++ {
+		s := make([]Interval[E], rnd.Intn(12))
+		weights := make([]float64, len(s))
+
+		for i := range s {
+			lo := E(rnd.Intn(20))
+			s[i] = Interval[E]{lo, lo + 1 + E(rnd.Intn(6))}
+			weights[i] = float64(rnd.Intn(10))
+		}
+
+		maxCount, maxWeight := 0, 0.0
+
+		for mask := 0; mask < 1<<len(s); mask++ {
+			count, weight, ok := 0, 0.0, true
+
+			for i := range s {
+				if mask&(1<<i) == 0 {
+					continue
+				}
+
+				for j := 0; j < i; j++ {
+					if mask&(1<<j) != 0 && s[i].Low < s[j].High && s[j].Low < s[i].High {
+						ok = false
+					}
+				}
+
+				count++
+				weight += weights[i]
+			}
+
+			if ok {
+				maxCount = max(maxCount, count)
+				maxWeight = max(maxWeight, weight)
+			}
+		}
+
+		if got := MaxDisjoint(s); len(got) != maxCount || !isDisjoint(s, got) {
+			t.Fatalf("MaxDisjoint(%v): want %v intervals, but got %v", s, maxCount, got)
+		}
+
+		got, total := MaxWeightDisjoint(s, weights)
+		if total != maxWeight || !isDisjoint(s, got) {
+			t.Fatalf("MaxWeightDisjoint(%v, %v): want %v, but got %v %v", s, weights, maxWeight, got, total)
+		}
+
+		sum := 0.0
+		for _, i := range got {
+			sum += weights[i]
+		}
+
+		if sum != total {
+			t.Fatalf("MaxWeightDisjoint(%v, %v): total %v does not match %v", s, weights, total, got)
+		}
+	}
+}
+
+// isDisjoint reports whether s[i] for each i in indices are in ascending
+// order and pairwise disjoint.
+func isDisjoint[E Elem[E]](s []Interval[E], indices []int) bool {
+	for k := 1; k < len(indices); k++ {
+		if s[indices[k-1]].High.Compare(s[indices[k]].Low) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,10 @@
+package setfile
+
+// SetBatchSize sets the batch size of external set operations to n, and
+// returns a function that restores it.
+func SetBatchSize(n int) (restore func()) {
+	old := batchSize
+	batchSize = n
+
+	return func() { batchSize = old }
+}
@@ -0,0 +1,144 @@
+package setfile
+
+import (
+	"io"
+	"sort"
+
+	"github.com/b97tsk/intervals"
+)
+
+// batchSize is the maximum number of intervals read from each input at
+// a time by external set operations.
+var batchSize = 4096
+
+// Union reads two encoded sets from x and y, and writes the encoding of
+// the set of elements that are in either x, or y, or both to w.
+// Union uses a bounded amount of memory, regardless of the size of inputs.
+func Union[E Integer[E]](w io.Writer, x, y io.Reader) error {
+	return external(w, x, y, intervals.Union[E])
+}
+
+// Intersection reads two encoded sets from x and y, and writes the encoding
+// of the set of elements that are in both x and y to w.
+// Intersection uses a bounded amount of memory, regardless of the size of
+// inputs.
+func Intersection[E Integer[E]](w io.Writer, x, y io.Reader) error {
+	return external(w, x, y, intervals.Intersection[E])
+}
+
+// Difference reads two encoded sets from x and y, and writes the encoding
+// of the set of elements that are in x, but not in y to w.
+// Difference uses a bounded amount of memory, regardless of the size of
+// inputs.
+func Difference[E Integer[E]](w io.Writer, x, y io.Reader) error {
+	return external(w, x, y, intervals.Difference[E])
+}
+
+// SymmetricDifference reads two encoded sets from x and y, and writes the
+// encoding of the set of elements that are in one of x and y, but not in
+// both to w.
+// SymmetricDifference uses a bounded amount of memory, regardless of the
+// size of inputs.
+func SymmetricDifference[E Integer[E]](w io.Writer, x, y io.Reader) error {
+	return external(w, x, y, intervals.SymmetricDifference[E])
+}
+
+// external applies set operation op over x and y batch by batch, writing
+// the result to w.
+//
+// Each round, it reads up to batchSize intervals from each input, and finds
+// a limit, the smaller one of the Highs of the last intervals read from
+// inputs that are not yet exhausted. Elements below limit are all known to
+// both inputs, so op can be applied to them. Elements above limit are left
+// to the next round. Adjacent intervals in the result that are separated
+// by limit are merged by the Encoder.
+func external[E Integer[E]](w io.Writer, x, y io.Reader, op func(z, x, y intervals.Set[E]) intervals.Set[E]) error {
+	enc := NewEncoder[E](w)
+	bx := batch[E]{d: NewDecoder[E](x)}
+	by := batch[E]{d: NewDecoder[E](y)}
+
+	var z intervals.Set[E]
+
+	for {
+		if err := bx.fill(); err != nil {
+			return err
+		}
+
+		if err := by.fill(); err != nil {
+			return err
+		}
+
+		if len(bx.s) == 0 && len(by.s) == 0 {
+			break
+		}
+
+		var limit E
+
+		switch {
+		case bx.eof && by.eof:
+			z = op(z, bx.s, by.s)
+			bx.s, by.s = bx.s[:0], by.s[:0]
+		case bx.eof:
+			limit = by.s[len(by.s)-1].High
+			z = op(z, bx.cut(limit), by.cut(limit))
+		case by.eof:
+			limit = bx.s[len(bx.s)-1].High
+			z = op(z, bx.cut(limit), by.cut(limit))
+		default:
+			limit = min(bx.s[len(bx.s)-1].High, by.s[len(by.s)-1].High)
+			z = op(z, bx.cut(limit), by.cut(limit))
+		}
+
+		for _, r := range z {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return enc.Flush()
+}
+
+// A batch holds intervals read from a Decoder but not yet processed.
+type batch[E Integer[E]] struct {
+	d    *Decoder[E]
+	s    intervals.Set[E] // Pending intervals.
+	head intervals.Set[E] // Intervals returned by cut.
+	eof  bool
+}
+
+// fill reads intervals from b.d until there are batchSize pending intervals
+// or the input is exhausted.
+func (b *batch[E]) fill() error {
+	for !b.eof && len(b.s) < batchSize {
+		r, err := b.d.Next()
+
+		switch err {
+		case nil:
+			b.s = append(b.s, r)
+		case io.EOF:
+			b.eof = true
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cut removes and returns pending elements that are less than limit.
+func (b *batch[E]) cut(limit E) intervals.Set[E] {
+	i := sort.Search(len(b.s), func(i int) bool { return b.s[i].Low >= limit })
+
+	b.head = append(b.head[:0], b.s[:i]...)
+
+	if i > 0 && b.s[i-1].High > limit {
+		b.head[i-1].High = limit
+		b.s[i-1].Low = limit
+		i--
+	}
+
+	b.s = append(b.s[:0], b.s[i:]...)
+
+	return b.head
+}
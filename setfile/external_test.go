@@ -0,0 +1,94 @@
+package setfile_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/setfile"
+)
+
+func TestExternal(t *testing.T) {
+	type E = elems.Uint16
+
+	testCases := []struct {
+		Name     string
+		External func(w *bytes.Buffer, x, y *bytes.Reader) error
+		Internal func(z, x, y intervals.Set[E]) intervals.Set[E]
+	}{
+		{
+			"Union",
+			func(w *bytes.Buffer, x, y *bytes.Reader) error { return setfile.Union[E](w, x, y) },
+			intervals.Union[E],
+		},
+		{
+			"Intersection",
+			func(w *bytes.Buffer, x, y *bytes.Reader) error { return setfile.Intersection[E](w, x, y) },
+			intervals.Intersection[E],
+		},
+		{
+			"Difference",
+			func(w *bytes.Buffer, x, y *bytes.Reader) error { return setfile.Difference[E](w, x, y) },
+			intervals.Difference[E],
+		},
+		{
+			"SymmetricDifference",
+			func(w *bytes.Buffer, x, y *bytes.Reader) error { return setfile.SymmetricDifference[E](w, x, y) },
+			intervals.SymmetricDifference[E],
+		},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+
+	for _, batchSize := range []int{1, 2, 3, 7, 4096} {
+		restore := setfile.SetBatchSize(batchSize)
+
+		for i := 0; i < 100; i++ {
+			x, y := randomSet(rng), randomSet(rng)
+
+			if i == 0 {
+				x = nil
+			}
+
+			for _, c := range testCases {
+				var buf bytes.Buffer
+
+				if err := c.External(&buf, bytes.NewReader(setfile.Append(nil, x)), bytes.NewReader(setfile.Append(nil, y))); err != nil {
+					t.Fatal(err)
+				}
+
+				r, err := setfile.NewReader[E](buf.Bytes())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := r.Set(), c.Internal(nil, x, y); !got.Equal(want) {
+					t.Fatalf("%v (batch size %v): x = %v, y = %v: want %v, but got %v", c.Name, batchSize, x, y, want, got)
+				}
+			}
+		}
+
+		restore()
+	}
+
+	var buf bytes.Buffer
+
+	bad := bytes.NewReader([]byte("ivs0"))
+
+	if err := setfile.Union[E](&buf, bad, bytes.NewReader(setfile.Append[E](nil, nil))); err == nil {
+		t.Fatal("Union didn't fail on an invalid input")
+	}
+}
+
+func randomSet(rng *rand.Rand) intervals.Set[elems.Uint16] {
+	var s []intervals.Interval[elems.Uint16]
+
+	for i, n := 0, rng.Intn(50); i < n; i++ {
+		lo := elems.Uint16(rng.Intn(1000))
+		s = append(s, intervals.Range(lo, lo+elems.Uint16(1+rng.Intn(30))))
+	}
+
+	return intervals.Collect(s...)
+}
@@ -0,0 +1,258 @@
+// Package setfile provides a fixed-width binary format for Sets of integer
+// elements, which is suitable for memory-mapping, along with set operations
+// that stream encoded Sets with bounded memory.
+//
+// The format consists of an 8-byte header, followed by intervals of the Set
+// in ascending order. The header is made of a 4-byte magic "ivs1", a byte
+// for the width of elements in bytes (1, 2, 4 or 8), a byte that is 1 for
+// signed elements or 0 for unsigned elements, and two zero bytes. Each
+// interval is encoded as its Low followed by its High, both in little-endian
+// order. There is no length field; the number of intervals is determined by
+// the length of the data.
+package setfile
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"unsafe"
+
+	"github.com/b97tsk/intervals"
+)
+
+// Integer is the type set containing all supported element types.
+type Integer[E any] interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+	intervals.Elem[E]
+}
+
+// HeaderSize is the size of the header in bytes.
+const HeaderSize = 8
+
+const magic = "ivs1"
+
+var (
+	errInvalidHeader = errors.New("setfile: invalid header")
+	errInvalidLength = errors.New("setfile: invalid length")
+	errInvalidData   = errors.New("setfile: intervals are not sorted or not separate")
+)
+
+func width[E Integer[E]]() int {
+	return int(unsafe.Sizeof(E(0)))
+}
+
+func signed[E Integer[E]]() bool {
+	return ^E(0) < 0
+}
+
+func appendHeader[E Integer[E]](b []byte) []byte {
+	var s byte
+	if signed[E]() {
+		s = 1
+	}
+
+	return append(append(b, magic...), byte(width[E]()), s, 0, 0)
+}
+
+func checkHeader[E Integer[E]](b []byte) error {
+	var s byte
+	if signed[E]() {
+		s = 1
+	}
+
+	if len(b) < HeaderSize || string(b[:4]) != magic || int(b[4]) != width[E]() || b[5] != s || b[6] != 0 || b[7] != 0 {
+		return errInvalidHeader
+	}
+
+	return nil
+}
+
+func appendElem[E Integer[E]](b []byte, v E) []byte {
+	switch width[E]() {
+	case 1:
+		return append(b, byte(v))
+	case 2:
+		return binary.LittleEndian.AppendUint16(b, uint16(v))
+	case 4:
+		return binary.LittleEndian.AppendUint32(b, uint32(v))
+	default:
+		return binary.LittleEndian.AppendUint64(b, uint64(v))
+	}
+}
+
+func elemAt[E Integer[E]](b []byte) E {
+	switch width[E]() {
+	case 1:
+		if signed[E]() {
+			return E(int8(b[0]))
+		}
+
+		return E(b[0])
+	case 2:
+		if signed[E]() {
+			return E(int16(binary.LittleEndian.Uint16(b)))
+		}
+
+		return E(binary.LittleEndian.Uint16(b))
+	case 4:
+		if signed[E]() {
+			return E(int32(binary.LittleEndian.Uint32(b)))
+		}
+
+		return E(binary.LittleEndian.Uint32(b))
+	default:
+		return E(binary.LittleEndian.Uint64(b))
+	}
+}
+
+// Append appends the encoding of x to b, returning the extended buffer.
+func Append[E Integer[E]](b []byte, x intervals.Set[E]) []byte {
+	b = appendHeader[E](b)
+
+	for _, r := range x {
+		b = appendElem(appendElem(b, r.Low), r.High)
+	}
+
+	return b
+}
+
+// Write writes the encoding of x to w.
+func Write[E Integer[E]](w io.Writer, x intervals.Set[E]) error {
+	_, err := w.Write(Append(nil, x))
+	return err
+}
+
+// A Reader provides read-only access to an encoded Set, directly on the
+// encoded data, which can be memory-mapped from a file.
+// Reader methods do not allocate, except Set.
+type Reader[E Integer[E]] struct {
+	data []byte // Without the header.
+}
+
+// NewReader returns a Reader that reads from data, which must not be
+// modified while the Reader is in use.
+//
+// NewReader checks the header and the length of data. It does not check
+// whether intervals are sorted and separate, which takes O(n) time; use
+// Validate for that.
+func NewReader[E Integer[E]](data []byte) (*Reader[E], error) {
+	if err := checkHeader[E](data); err != nil {
+		return nil, err
+	}
+
+	data = data[HeaderSize:]
+
+	if len(data)%(2*width[E]()) != 0 {
+		return nil, errInvalidLength
+	}
+
+	return &Reader[E]{data}, nil
+}
+
+// Validate reports an error if intervals in x are not sorted in ascending
+// order or not separate.
+func (x *Reader[E]) Validate() error {
+	n := x.Len()
+
+	for i := 0; i < n; i++ {
+		r := x.At(i)
+
+		if r.Low >= r.High || i > 0 && x.At(i-1).High >= r.Low {
+			return errInvalidData
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of intervals in x.
+func (x *Reader[E]) Len() int {
+	return len(x.data) / (2 * width[E]())
+}
+
+// At returns the i-th interval in x.
+func (x *Reader[E]) At(i int) intervals.Interval[E] {
+	w := width[E]()
+	b := x.data[2*w*i:]
+
+	return intervals.Range(elemAt[E](b), elemAt[E](b[w:]))
+}
+
+// search returns the index of the first interval whose High is greater
+// than v.
+func (x *Reader[E]) search(v E) int {
+	return sort.Search(x.Len(), func(i int) bool { return x.At(i).High > v })
+}
+
+// Contains reports whether x contains every element in range [r.Low, r.High).
+func (x *Reader[E]) Contains(r intervals.Interval[E]) bool {
+	i := x.search(r.Low)
+	if i == x.Len() {
+		return false
+	}
+
+	s := x.At(i)
+
+	return s.Low <= r.Low && s.High >= r.High && r.Low < r.High
+}
+
+// ContainsUnit reports whether x contains a single element v.
+func (x *Reader[E]) ContainsUnit(v E) bool {
+	i := x.search(v)
+	return i < x.Len() && x.At(i).Low <= v
+}
+
+// Extent returns the smallest Interval that contains every element in x.
+//
+// If x is empty, Extent returns the zero value.
+func (x *Reader[E]) Extent() intervals.Interval[E] {
+	n := x.Len()
+	if n == 0 {
+		return intervals.Interval[E]{}
+	}
+
+	return intervals.Range(x.At(0).Low, x.At(n-1).High)
+}
+
+// Overlapping calls yield for each interval in x that overlaps r, in
+// ascending order, until yield returns false.
+func (x *Reader[E]) Overlapping(r intervals.Interval[E], yield func(intervals.Interval[E]) bool) {
+	if r.Low >= r.High {
+		return
+	}
+
+	for i, n := x.search(r.Low), x.Len(); i < n; i++ {
+		s := x.At(i)
+		if s.Low >= r.High || !yield(s) {
+			return
+		}
+	}
+}
+
+// All calls yield for each interval in x, in ascending order, until yield
+// returns false.
+func (x *Reader[E]) All(yield func(intervals.Interval[E]) bool) {
+	for i, n := 0, x.Len(); i < n; i++ {
+		if !yield(x.At(i)) {
+			return
+		}
+	}
+}
+
+// Set decodes x into a Set.
+func (x *Reader[E]) Set() intervals.Set[E] {
+	n := x.Len()
+	if n == 0 {
+		return nil
+	}
+
+	s := make(intervals.Set[E], n)
+
+	for i := range s {
+		s[i] = x.At(i)
+	}
+
+	return s
+}
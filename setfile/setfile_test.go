@@ -0,0 +1,151 @@
+package setfile_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/setfile"
+)
+
+func TestEncoding(t *testing.T) {
+	type E = elems.Int16
+
+	x := intervals.Collect(intervals.Range[E](-300, -1), intervals.Range[E](5, 7))
+	data := setfile.Append(nil, x)
+
+	if got, want := hex.EncodeToString(data), "69767331"+"0201"+"0000"+"d4fe"+"ffff"+"0500"+"0700"; got != want {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+
+	var buf bytes.Buffer
+
+	if err := setfile.Write(&buf, x); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("Write and Append didn't agree")
+	}
+
+	testRoundTrip(t, intervals.Collect(intervals.Range[elems.Int8](-128, -1), intervals.Range[elems.Int8](5, 127)))
+	testRoundTrip(t, intervals.Collect(intervals.Range[elems.Uint8](0, 3), intervals.Range[elems.Uint8](200, 255)))
+	testRoundTrip(t, intervals.Collect(intervals.Range[elems.Int32](-1<<31, -1), intervals.Range[elems.Int32](5, 1<<31-1)))
+	testRoundTrip(t, intervals.Collect(intervals.Range[elems.Uint32](1, 3), intervals.Range[elems.Uint32](1<<31, 1<<32-1)))
+	testRoundTrip(t, intervals.Collect(intervals.Range[elems.Int64](-1<<63, -1), intervals.Range[elems.Int64](5, 1<<63-1)))
+	testRoundTrip(t, intervals.Collect(intervals.Range[elems.Uint64](1, 3), intervals.Range[elems.Uint64](1<<63, 1<<64-1)))
+	testRoundTrip[elems.Int](t, nil)
+}
+
+func testRoundTrip[E setfile.Integer[E]](t *testing.T, x intervals.Set[E]) {
+	t.Helper()
+
+	r, err := setfile.NewReader[E](setfile.Append(nil, x))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r.Set(); !got.Equal(x) {
+		t.Fatalf("want %v, but got %v", x, got)
+	}
+}
+
+func TestReader(t *testing.T) {
+	type E = elems.Int
+
+	r, err := setfile.NewReader[E](setfile.Append(nil, intervals.Collect(intervals.Range[E](1, 3), intervals.Range[E](5, 7), intervals.Range[E](9, 11))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collect := func(each func(func(intervals.Interval[E]) bool), n int) intervals.Set[E] {
+		var s intervals.Set[E]
+
+		each(func(r intervals.Interval[E]) bool {
+			s = append(s, r)
+			return len(s) != n
+		})
+
+		return s
+	}
+
+	overlapping := func(q intervals.Interval[E]) func(func(intervals.Interval[E]) bool) {
+		return func(yield func(intervals.Interval[E]) bool) { r.Overlapping(q, yield) }
+	}
+
+	empty, _ := setfile.NewReader[E](setfile.Append[E](nil, nil))
+
+	assertions := []bool{
+		r.Len() == 3,
+		r.At(1) == intervals.Range[E](5, 7),
+		r.ContainsUnit(0) == false,
+		r.ContainsUnit(1) == true,
+		r.ContainsUnit(3) == false,
+		r.ContainsUnit(10) == true,
+		r.ContainsUnit(11) == false,
+		r.Contains(intervals.Range[E](5, 7)) == true,
+		r.Contains(intervals.Range[E](5, 8)) == false,
+		r.Contains(intervals.Range[E](6, 6)) == false,
+		r.Contains(intervals.Range[E](12, 13)) == false,
+		r.Extent() == intervals.Range[E](1, 11),
+		empty.Extent() == intervals.Interval[E]{},
+		empty.Set() == nil,
+		collect(r.All, -1).Equal(r.Set()),
+		collect(r.All, 1).Equal(r.Set()[:1]),
+		collect(overlapping(intervals.Range[E](2, 6)), -1).Equal(r.Set()[:2]),
+		collect(overlapping(intervals.Range[E](3, 5)), -1).Equal(nil),
+		collect(overlapping(intervals.Range[E](6, 20)), -1).Equal(r.Set()[1:]),
+		collect(overlapping(intervals.Range[E](6, 20)), 1).Equal(r.Set()[1:2]),
+		collect(overlapping(intervals.Range[E](6, 6)), -1).Equal(nil),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	type E = elems.Int16
+
+	for i, c := range []string{
+		"",
+		"697673",
+		"69767332" + "0201" + "0000",
+		"69767331" + "0401" + "0000",
+		"69767331" + "0200" + "0000",
+		"69767331" + "0201" + "0100",
+		"69767331" + "0201" + "0000" + "0100",
+	} {
+		data, _ := hex.DecodeString(c)
+
+		if _, err := setfile.NewReader[E](data); err == nil {
+			t.Errorf("Case %v: NewReader didn't fail", i)
+		}
+	}
+
+	for i, c := range []string{
+		"69767331" + "0201" + "0000" + "0300" + "0100",
+		"69767331" + "0201" + "0000" + "0100" + "0300" + "0300" + "0500",
+		"69767331" + "0201" + "0000" + "0100" + "0500" + "0300" + "0700",
+	} {
+		data, _ := hex.DecodeString(c)
+
+		r, err := setfile.NewReader[E](data)
+		if err != nil {
+			t.Fatalf("Case %v: %v", i, err)
+		}
+
+		if err := r.Validate(); err == nil {
+			t.Errorf("Case %v: Validate didn't fail", i)
+		}
+	}
+}
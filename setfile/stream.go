@@ -0,0 +1,163 @@
+package setfile
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/b97tsk/intervals"
+)
+
+var errUnsorted = errors.New("setfile: intervals are not sorted")
+
+// ErrFlushedAdjacent is returned by Encoder.Encode when an interval is
+// adjacent to the last interval, which has already been written by Flush.
+// Unlike other errors, it does not stop the Encoder.
+var ErrFlushedAdjacent = errors.New("setfile: cannot merge an interval into one already flushed")
+
+// An Encoder writes a Set to an output stream, one interval at a time.
+type Encoder[E Integer[E]] struct {
+	w       *bufio.Writer
+	buf     []byte
+	last    intervals.Interval[E]
+	has     bool // Whether last is valid.
+	pending bool // Whether last is not yet written.
+	started bool // Whether the header is written.
+	err     error
+}
+
+// NewEncoder returns an Encoder that writes to w.
+// Callers must call Flush after encoding.
+func NewEncoder[E Integer[E]](w io.Writer) *Encoder[E] {
+	return &Encoder[E]{w: bufio.NewWriter(w)}
+}
+
+// Encode writes r to the output stream. Intervals must be encoded in
+// ascending order and must not overlap each other; adjacent intervals are
+// merged into one. Intervals that contain no elements are ignored.
+//
+// Since Flush writes out the last encoded interval, an interval adjacent to
+// it can no longer be merged after Flush. In that case, Encode returns
+// ErrFlushedAdjacent without writing r, and the Encoder remains usable.
+// Any other error is permanent; subsequent calls return the same error.
+func (e *Encoder[E]) Encode(r intervals.Interval[E]) error {
+	if e.err != nil || r.Low >= r.High {
+		return e.err
+	}
+
+	if e.has {
+		switch {
+		case r.Low < e.last.High:
+			e.err = errUnsorted
+			return e.err
+		case r.Low == e.last.High && !e.pending:
+			return ErrFlushedAdjacent
+		case r.Low == e.last.High:
+			e.last.High = r.High
+			return nil
+		}
+
+		if e.pending {
+			e.write()
+		}
+	}
+
+	e.last, e.has, e.pending = r, true, true
+
+	return e.err
+}
+
+func (e *Encoder[E]) write() {
+	if !e.started {
+		e.buf = appendHeader[E](e.buf[:0])
+		e.started = true
+	} else {
+		e.buf = e.buf[:0]
+	}
+
+	if e.pending {
+		e.buf = appendElem(appendElem(e.buf, e.last.Low), e.last.High)
+		e.pending = false
+	}
+
+	if e.err == nil {
+		_, e.err = e.w.Write(e.buf)
+	}
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+// If nothing has been encoded, Flush writes the encoding of an empty set.
+func (e *Encoder[E]) Flush() error {
+	if e.pending || !e.started {
+		e.write()
+	}
+
+	if e.err == nil {
+		e.err = e.w.Flush()
+	}
+
+	return e.err
+}
+
+// A Decoder reads a Set from an input stream, one interval at a time.
+type Decoder[E Integer[E]] struct {
+	r       *bufio.Reader
+	buf     []byte
+	last    intervals.Interval[E]
+	started bool // Whether the header is read.
+	n       int  // Number of intervals read.
+	err     error
+}
+
+// NewDecoder returns a Decoder that reads from r.
+// The Decoder may read data from r beyond the intervals requested.
+func NewDecoder[E Integer[E]](r io.Reader) *Decoder[E] {
+	return &Decoder[E]{r: bufio.NewReader(r), buf: make([]byte, max(HeaderSize, 2*width[E]()))}
+}
+
+// Next returns the next interval in the input stream.
+// At the end of the input stream, Next returns io.EOF.
+// Next reports an error if intervals are not sorted in ascending order or
+// not separate.
+func (d *Decoder[E]) Next() (intervals.Interval[E], error) {
+	if d.err != nil {
+		return intervals.Interval[E]{}, d.err
+	}
+
+	if !d.started {
+		d.started = true
+
+		if _, err := io.ReadFull(d.r, d.buf[:HeaderSize]); err != nil {
+			d.err = errInvalidHeader
+			return intervals.Interval[E]{}, d.err
+		}
+
+		if d.err = checkHeader[E](d.buf); d.err != nil {
+			return intervals.Interval[E]{}, d.err
+		}
+	}
+
+	w := width[E]()
+
+	if _, err := io.ReadFull(d.r, d.buf[:2*w]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errInvalidLength
+		}
+
+		d.err = err
+
+		return intervals.Interval[E]{}, d.err
+	}
+
+	r := intervals.Range(elemAt[E](d.buf), elemAt[E](d.buf[w:]))
+
+	if r.Low >= r.High || d.n > 0 && d.last.High >= r.Low {
+		d.err = errInvalidData
+		return intervals.Interval[E]{}, d.err
+	}
+
+	d.last = r
+	d.n++
+
+	return r, nil
+}
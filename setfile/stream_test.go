@@ -0,0 +1,151 @@
+package setfile_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+	"github.com/b97tsk/intervals/setfile"
+)
+
+func TestEncoder(t *testing.T) {
+	type E = elems.Int16
+
+	var buf bytes.Buffer
+
+	enc := setfile.NewEncoder[E](&buf)
+
+	for _, r := range []intervals.Interval[E]{
+		intervals.Range[E](-300, -100),
+		intervals.Range[E](-100, -1),
+		intervals.Range[E](3, 3),
+		intervals.Range[E](5, 7),
+	} {
+		if err := enc.Encode(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := setfile.Append(nil, intervals.Collect(intervals.Range[E](-300, -1), intervals.Range[E](5, 7)))
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("want %x, but got %x", want, buf.Bytes())
+	}
+
+	if err := enc.Encode(intervals.Range[E](6, 9)); err == nil {
+		t.Fatal("Encode didn't fail on an overlapping interval")
+	}
+
+	if err := enc.Flush(); err == nil {
+		t.Fatal("Flush didn't report the previous error")
+	}
+
+	buf.Reset()
+
+	empty := setfile.NewEncoder[E](&buf)
+
+	if err := empty.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := setfile.Append[E](nil, nil); !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("want %x, but got %x", want, buf.Bytes())
+	}
+}
+
+func TestEncoderFlush(t *testing.T) {
+	type E = elems.Int16
+
+	var buf bytes.Buffer
+
+	enc := setfile.NewEncoder[E](&buf)
+
+	if err := enc.Encode(intervals.Range[E](1, 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := enc.Encode(intervals.Range[E](3, 5)); !errors.Is(err, setfile.ErrFlushedAdjacent) {
+		t.Fatalf("want ErrFlushedAdjacent, but got %v", err)
+	}
+
+	if err := enc.Encode(intervals.Range[E](7, 9)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := enc.Encode(intervals.Range[E](9, 11)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := setfile.Append(nil, intervals.Collect(intervals.Range[E](1, 3), intervals.Range[E](7, 11)))
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("want %x, but got %x", want, buf.Bytes())
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	type E = elems.Int16
+
+	x := intervals.Collect(intervals.Range[E](-300, -1), intervals.Range[E](5, 7))
+	dec := setfile.NewDecoder[E](bytes.NewReader(setfile.Append(nil, x)))
+
+	var got intervals.Set[E]
+
+	for {
+		r, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got = append(got, r)
+	}
+
+	if !got.Equal(x) {
+		t.Fatalf("want %v, but got %v", x, got)
+	}
+
+	if _, err := dec.Next(); err != io.EOF {
+		t.Fatalf("want %v, but got %v", io.EOF, err)
+	}
+
+	for i, c := range []string{
+		"",
+		"69767331" + "0401" + "0000",
+		"69767331" + "0201" + "0000" + "0100",
+		"69767331" + "0201" + "0000" + "0300" + "0100",
+		"69767331" + "0201" + "0000" + "0100" + "0300" + "0300" + "0500",
+	} {
+		data, _ := hex.DecodeString(c)
+		dec := setfile.NewDecoder[E](bytes.NewReader(data))
+
+		var err error
+
+		for err == nil {
+			_, err = dec.Next()
+		}
+
+		if errors.Is(err, io.EOF) {
+			t.Errorf("Case %v: Next didn't fail", i)
+		}
+	}
+}
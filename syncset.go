@@ -0,0 +1,93 @@
+package intervals
+
+import "sync/atomic"
+
+// A SyncSet is a Set that is safe for concurrent use by multiple goroutines.
+// Updates are copy-on-write: every update stores a new Set, while readers
+// work on immutable snapshots and never block.
+//
+// A SyncSet suits read-mostly workloads; each update copies the whole Set.
+//
+// The zero value for a SyncSet is an empty set ready to use.
+// A SyncSet must not be copied after first use.
+type SyncSet[E Elem[E]] struct {
+	p atomic.Pointer[Set[E]]
+}
+
+// Load returns a snapshot of s.
+// The returned Set is shared and must not be modified.
+func (s *SyncSet[E]) Load() Set[E] {
+	if p := s.p.Load(); p != nil {
+		return *p
+	}
+
+	return nil
+}
+
+// Store sets s to x. s takes ownership of x; x must not be modified after.
+func (s *SyncSet[E]) Store(x Set[E]) {
+	s.p.Store(&x)
+}
+
+// CompareAndSwap sets s to new, if s is still old, which must be a snapshot
+// previously returned by Load (or by Update), and reports whether it did.
+// If it did, s takes ownership of new; new must not be modified after.
+func (s *SyncSet[E]) CompareAndSwap(old, new Set[E]) bool {
+	p := s.p.Load()
+
+	var cur Set[E]
+	if p != nil {
+		cur = *p
+	}
+
+	if len(cur) != len(old) || len(cur) != 0 && &cur[0] != &old[0] {
+		return false
+	}
+
+	if p == nil {
+		return s.p.CompareAndSwap(nil, &new)
+	}
+
+	return s.p.CompareAndSwap(p, &new)
+}
+
+// Update atomically replaces s with f(x), where x is a copy of the current
+// snapshot of s that f may modify, and returns the new snapshot.
+// f may be called more than once if other goroutines update s concurrently.
+func (s *SyncSet[E]) Update(f func(x Set[E]) Set[E]) Set[E] {
+	for {
+		old := s.Load()
+		new := f(append(make(Set[E], 0, len(old)+1), old...))
+
+		if s.CompareAndSwap(old, new) {
+			return new
+		}
+	}
+}
+
+// Add atomically adds zero or more Intervals into s.
+func (s *SyncSet[E]) Add(r ...Interval[E]) {
+	s.Update(func(x Set[E]) Set[E] { return Add(x, r...) })
+}
+
+// Delete atomically removes zero or more Intervals from s.
+func (s *SyncSet[E]) Delete(r ...Interval[E]) {
+	s.Update(func(x Set[E]) Set[E] { return Delete(x, r...) })
+}
+
+// Contains reports whether s contains every element in range [r.Low, r.High).
+func (s *SyncSet[E]) Contains(r Interval[E]) bool {
+	return s.Load().Contains(r)
+}
+
+// ContainsUnit reports whether s contains a single element v.
+func (s *SyncSet[E]) ContainsUnit(v E) bool {
+	return s.Load().ContainsUnit(v)
+}
+
+// Extent returns the smallest Interval that contains every element in s.
+//
+// If s is empty, Extent returns the zero value.
+func (s *SyncSet[E]) Extent() Interval[E] {
+	return s.Load().Extent()
+}
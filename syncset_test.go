@@ -0,0 +1,86 @@
+package intervals_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestSyncSet(t *testing.T) {
+	type E = elems.Int
+
+	var s SyncSet[E]
+
+	s.Add(Range[E](1, 5), Range[E](11, 15))
+	s.Delete(Range[E](2, 3))
+
+	snapshot := s.Load()
+
+	s.Add(Range[E](5, 11))
+
+	assertions := []bool{
+		snapshot.Equal(Set[E]{{1, 2}, {3, 5}, {11, 15}}),
+		s.Load().Equal(Set[E]{{1, 2}, {3, 15}}),
+		s.ContainsUnit(1) == true,
+		s.ContainsUnit(2) == false,
+		s.Contains(Range[E](3, 15)) == true,
+		s.Contains(Range[E](1, 3)) == false,
+		s.Extent() == Range[E](1, 15),
+		s.CompareAndSwap(snapshot, nil) == false,
+		s.CompareAndSwap(s.Load(), Set[E]{{7, 9}}) == true,
+		s.Load().Equal(Set[E]{{7, 9}}),
+		new(SyncSet[E]).Load() == nil,
+		new(SyncSet[E]).CompareAndSwap(nil, Set[E]{{7, 9}}) == true,
+		new(SyncSet[E]).CompareAndSwap(Set[E]{{7, 9}}, nil) == false,
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}
+
+func TestSyncSetConcurrency(t *testing.T) {
+	type E = elems.Int
+
+	const n = 100
+
+	var s SyncSet[E]
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			s.Add(Unit(E(2 * i)))
+			s.Add(Unit(E(2*i + 1)))
+			s.Delete(Unit(E(2*i + 1)))
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_ = s.ContainsUnit(E(2 * i))
+			_ = s.Load().Extent()
+		}(i)
+	}
+
+	wg.Wait()
+
+	var want Set[E]
+
+	for i := 0; i < n; i++ {
+		want = Add(want, Unit(E(2*i)))
+	}
+
+	if got := s.Load(); !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
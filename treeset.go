@@ -0,0 +1,280 @@
+package intervals
+
+// A TreeSet is a set of separate intervals stored in a balanced binary
+// search tree (a treap), which makes Add and Delete run in O(log n) expected
+// time, instead of O(n) for Set.
+// The zero value for a TreeSet is an empty set ready to use.
+//
+// A TreeSet must not be copied after first use.
+type TreeSet[E Elem[E]] struct {
+	root *treeNode[E]
+	len  int
+	seed prng
+}
+
+type treeNode[E Elem[E]] struct {
+	r           Interval[E]
+	prio        uint64
+	left, right *treeNode[E]
+}
+
+// NewTreeSet returns a TreeSet that contains the same elements as x.
+// NewTreeSet runs in O(n) time.
+func NewTreeSet[E Elem[E]](x Set[E]) *TreeSet[E] {
+	t := new(TreeSet[E])
+
+	// Build a Cartesian tree from x, which is already sorted.
+	var stack []*treeNode[E]
+
+	for _, r := range x {
+		n := &treeNode[E]{r: r, prio: t.seed.next()}
+
+		var last *treeNode[E]
+
+		for len(stack) != 0 && stack[len(stack)-1].prio < n.prio {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+
+		n.left = last
+
+		if len(stack) != 0 {
+			stack[len(stack)-1].right = n
+		}
+
+		stack = append(stack, n)
+	}
+
+	if len(stack) != 0 {
+		t.root = stack[0]
+	}
+
+	t.len = len(x)
+
+	return t
+}
+
+// Len returns the number of intervals in t.
+func (t *TreeSet[E]) Len() int {
+	return t.len
+}
+
+// Add adds zero or more Intervals into t.
+func (t *TreeSet[E]) Add(s ...Interval[E]) {
+	for _, r := range s {
+		t.addRange(r.Low, r.High)
+	}
+}
+
+func (t *TreeSet[E]) addRange(lo, hi E) {
+	if lo.Compare(hi) >= 0 {
+		return
+	}
+
+	// Intervals in m overlap or are adjacent to [lo, hi).
+	l, m := splitTree(t.root, func(r Interval[E]) bool { return r.High.Compare(lo) < 0 })
+	m, r := splitTree(m, func(r Interval[E]) bool { return r.Low.Compare(hi) <= 0 })
+
+	if m != nil {
+		if v := leftmost(m).r.Low; v.Compare(lo) < 0 {
+			lo = v
+		}
+
+		if v := rightmost(m).r.High; v.Compare(hi) > 0 {
+			hi = v
+		}
+
+		t.len -= countTree(m)
+	}
+
+	n := &treeNode[E]{r: Range(lo, hi), prio: t.seed.next()}
+	t.root = mergeTree(mergeTree(l, n), r)
+	t.len++
+}
+
+// Delete removes zero or more Intervals from t.
+func (t *TreeSet[E]) Delete(s ...Interval[E]) {
+	for _, r := range s {
+		t.deleteRange(r.Low, r.High)
+	}
+}
+
+func (t *TreeSet[E]) deleteRange(lo, hi E) {
+	if lo.Compare(hi) >= 0 {
+		return
+	}
+
+	// Intervals in m overlap [lo, hi).
+	l, m := splitTree(t.root, func(r Interval[E]) bool { return r.High.Compare(lo) <= 0 })
+	m, r := splitTree(m, func(r Interval[E]) bool { return r.Low.Compare(hi) < 0 })
+
+	if m == nil {
+		t.root = mergeTree(l, r)
+		return
+	}
+
+	if v := leftmost(m).r.Low; v.Compare(lo) < 0 {
+		l = mergeTree(l, &treeNode[E]{r: Range(v, lo), prio: t.seed.next()})
+		t.len++
+	}
+
+	if v := rightmost(m).r.High; v.Compare(hi) > 0 {
+		r = mergeTree(&treeNode[E]{r: Range(hi, v), prio: t.seed.next()}, r)
+		t.len++
+	}
+
+	t.len -= countTree(m)
+	t.root = mergeTree(l, r)
+}
+
+// Contains reports whether t contains every element in range [r.Low, r.High).
+func (t *TreeSet[E]) Contains(r Interval[E]) bool {
+	n := t.search(r.Low)
+	return n != nil && n.r.Low.Compare(r.Low) <= 0 && n.r.High.Compare(r.High) >= 0 && r.Low.Compare(r.High) < 0
+}
+
+// ContainsUnit reports whether t contains a single element v.
+func (t *TreeSet[E]) ContainsUnit(v E) bool {
+	n := t.search(v)
+	return n != nil && n.r.Low.Compare(v) <= 0
+}
+
+// search returns the first node whose High is greater than v, or nil if
+// there is no such node.
+func (t *TreeSet[E]) search(v E) *treeNode[E] {
+	var found *treeNode[E]
+
+	for n := t.root; n != nil; {
+		if n.r.High.Compare(v) > 0 {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return found
+}
+
+// Extent returns the smallest Interval that contains every element in t.
+//
+// If t is empty, Extent returns the zero value.
+func (t *TreeSet[E]) Extent() Interval[E] {
+	if t.root == nil {
+		return Interval[E]{}
+	}
+
+	return Range(leftmost(t.root).r.Low, rightmost(t.root).r.High)
+}
+
+// All calls yield for each Interval in t, in ascending order, until yield
+// returns false.
+func (t *TreeSet[E]) All(yield func(Interval[E]) bool) {
+	walkTree(t.root, yield)
+}
+
+// Set returns the set of elements that are in t.
+func (t *TreeSet[E]) Set() Set[E] {
+	if t.root == nil {
+		return nil
+	}
+
+	x := make(Set[E], 0, t.len)
+
+	walkTree(t.root, func(r Interval[E]) bool {
+		x = append(x, r)
+		return true
+	})
+
+	return x
+}
+
+// splitTree splits n into two trees, the first one contains nodes whose
+// interval satisfies pred, the second one contains the rest.
+// pred must be true for a prefix of the intervals in n and false after.
+func splitTree[E Elem[E]](n *treeNode[E], pred func(Interval[E]) bool) (l, r *treeNode[E]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if pred(n.r) {
+		n.right, r = splitTree(n.right, pred)
+		return n, r
+	}
+
+	l, n.left = splitTree(n.left, pred)
+
+	return l, n
+}
+
+// mergeTree merges two trees into one. Intervals in l must all precede
+// those in r.
+func mergeTree[E Elem[E]](l, r *treeNode[E]) *treeNode[E] {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.prio > r.prio:
+		l.right = mergeTree(l.right, r)
+		return l
+	default:
+		r.left = mergeTree(l, r.left)
+		return r
+	}
+}
+
+func leftmost[E Elem[E]](n *treeNode[E]) *treeNode[E] {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+func rightmost[E Elem[E]](n *treeNode[E]) *treeNode[E] {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n
+}
+
+func countTree[E Elem[E]](n *treeNode[E]) int {
+	if n == nil {
+		return 0
+	}
+
+	return 1 + countTree(n.left) + countTree(n.right)
+}
+
+func walkTree[E Elem[E]](n *treeNode[E], yield func(Interval[E]) bool) bool {
+	for n != nil {
+		if !walkTree(n.left, yield) || !yield(n.r) {
+			return false
+		}
+
+		n = n.right
+	}
+
+	return true
+}
+
+// prng is a SplitMix64 pseudo-random number generator, for use as a source
+// of node priorities.
+type prng uint64
+
+const prngGamma = 0x9e3779b97f4a7c15
+
+func (p *prng) next() uint64 {
+	*p += prngGamma
+	return mix64(uint64(*p))
+}
+
+// mix64 is the output function of SplitMix64.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+
+	return z ^ (z >> 31)
+}
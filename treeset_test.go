@@ -0,0 +1,107 @@
+package intervals_test
+
+import (
+	"testing"
+
+	. "github.com/b97tsk/intervals"
+	"github.com/b97tsk/intervals/elems"
+)
+
+func TestTreeSet(t *testing.T) {
+	type E = elems.Int
+
+	testCases := []struct {
+		Actual, Expected Set[E]
+	}{
+		{
+			func() Set[E] {
+				var t TreeSet[E]
+				t.Add(Range[E](1, 5), Range[E](11, 15), Range[E](5, 11))
+				return t.Set()
+			}(),
+			Set[E]{{1, 15}},
+		},
+		{
+			func() Set[E] {
+				var t TreeSet[E]
+				t.Add(Range[E](11, 15), Range[E](1, 5), Range[E](7, 9), Range[E](9, 7))
+				return t.Set()
+			}(),
+			Set[E]{{1, 5}, {7, 9}, {11, 15}},
+		},
+		{
+			func() Set[E] {
+				t := NewTreeSet(Set[E]{{1, 3}, {5, 11}, {13, 15}})
+				t.Delete(Range[E](7, 9), Range[E](2, 6), Range[E](15, 13))
+				return t.Set()
+			}(),
+			Set[E]{{1, 2}, {6, 7}, {9, 11}, {13, 15}},
+		},
+		{
+			func() Set[E] {
+				t := NewTreeSet(Set[E]{{1, 3}, {5, 11}, {13, 15}})
+				t.Delete(Range[E](0, 20))
+				return t.Set()
+			}(),
+			nil,
+		},
+		{
+			NewTreeSet[E](nil).Set(),
+			nil,
+		},
+	}
+
+	for i, c := range testCases {
+		if !c.Actual.Equal(c.Expected) {
+			t.Fail()
+			t.Logf("Case %v: want %v, but got %v", i, c.Expected, c.Actual)
+		}
+	}
+}
+
+func TestTreeSetQueries(t *testing.T) {
+	type E = elems.Int
+
+	s := NewTreeSet(Set[E]{{1, 3}, {5, 7}})
+
+	var all Set[E]
+
+	s.All(func(r Interval[E]) bool {
+		all = append(all, r)
+		return true
+	})
+
+	var first Set[E]
+
+	s.All(func(r Interval[E]) bool {
+		first = append(first, r)
+		return false
+	})
+
+	assertions := []bool{
+		s.Len() == 2,
+		s.ContainsUnit(0) == false,
+		s.ContainsUnit(1) == true,
+		s.ContainsUnit(2) == true,
+		s.ContainsUnit(3) == false,
+		s.ContainsUnit(6) == true,
+		s.ContainsUnit(7) == false,
+		s.Contains(Range[E](1, 3)) == true,
+		s.Contains(Range[E](3, 5)) == false,
+		s.Contains(Range[E](5, 7)) == true,
+		s.Contains(Range[E](1, 7)) == false,
+		s.Contains(Range[E](2, 2)) == false,
+		s.Extent() == Range[E](1, 7),
+		new(TreeSet[E]).Extent() == Interval[E]{},
+		new(TreeSet[E]).Len() == 0,
+		all.Equal(Set[E]{{1, 3}, {5, 7}}),
+		first.Equal(Set[E]{{1, 3}}),
+	}
+
+	for i, ok := range assertions {
+		if !ok {
+			t.Fail()
+			t.Logf("Case %v: FAILED", i)
+		}
+	}
+}